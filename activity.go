@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Append-only activity journal, one JSON object per line next to config.json
+
+type ActivityEntry struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+	Kind  string    `json:"kind"`
+	ID    int       `json:"id,omitempty"`
+	Name  string    `json:"name,omitempty"`
+}
+
+// Item kind recorded in the journal, by tab
+var activityKinds = map[int]string{
+	2: "daily",
+	3: "todo",
+	4: "reminder",
+	5: "glossary",
+	6: "project",
+}
+
+// Journal event for each reminder action
+var reminderEvents = map[string]string{
+	"start": "started",
+	"pause": "paused",
+	"reset": "reset",
+}
+
+func activityPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "lif", "activity.jsonl"), nil
+}
+
+func appendActivity(entries ...ActivityEntry) error {
+	path, err := activityPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadActivity reads the journal, oldest first. Lines that don't parse (a
+// write cut short by a crash) are skipped.
+func loadActivity() ([]ActivityEntry, error) {
+	path, err := activityPath()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := []ActivityEntry{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry ActivityEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// logActivity records one change. The journal is best effort: a failed write
+// is shown in the status line but never blocks the change itself.
+func (m *model) logActivity(event, kind string, id int, name string) {
+	err := appendActivity(ActivityEntry{Time: time.Now(), Event: event, Kind: kind, ID: id, Name: name})
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Could not write activity journal: %v", err)
+		m.statusColor = "196"
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+	}
+}
+
+// logItem records a change to the item at idx on a tab
+func (m *model) logItem(event string, tab, idx int) {
+	var id int
+	var name string
+	switch tab {
+	case 2:
+		id, name = m.data.Dailies[idx].ID, m.data.Dailies[idx].Task
+	case 3:
+		id, name = m.data.RollingTodos[idx].ID, m.data.RollingTodos[idx].Task
+	case 4:
+		id, name = m.data.Reminders[idx].ID, m.data.Reminders[idx].Reminder
+	case 5:
+		id, name = m.data.Glossary[idx].ID, oneLine(m.data.Glossary[idx].Command)
+	case 6:
+		id, name = m.data.Projects[idx].ID, m.data.Projects[idx].Name
+	}
+	m.logActivity(event, activityKinds[tab], id, name)
+}
+
+func (m *model) itemCount(tab int) int {
+	switch tab {
+	case 2:
+		return len(m.data.Dailies)
+	case 3:
+		return len(m.data.RollingTodos)
+	case 4:
+		return len(m.data.Reminders)
+	case 5:
+		return len(m.data.Glossary)
+	case 6:
+		return len(m.data.Projects)
+	}
+	return 0
+}
+
+func formatActivity(entry ActivityEntry) string {
+	ref := entry.Kind
+	if entry.ID != 0 {
+		ref = fmt.Sprintf("%s #%d", entry.Kind, entry.ID)
+	}
+	return fmt.Sprintf("%s  %-10s %-14s %s", entry.Time.Format("2006-01-02 15:04"), entry.Event, ref, entry.Name)
+}
+
+// exportActivity writes the journal as CSV, or as a JSON array when json is set
+func exportActivity(w io.Writer, entries []ActivityEntry, asJSON bool) error {
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"time", "event", "kind", "id", "name"})
+	for _, entry := range entries {
+		writer.Write([]string{
+			entry.Time.Format(time.RFC3339),
+			entry.Event,
+			entry.Kind,
+			strconv.Itoa(entry.ID),
+			entry.Name,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func (m *model) startActivity() {
+	entries, err := loadActivity()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Could not read activity journal: %v", err)
+		m.statusColor = "196"
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+		return
+	}
+	if len(entries) == 0 {
+		m.statusMsg = "No activity recorded yet"
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(2 * time.Second)
+		return
+	}
+	m.activityOpen = true
+	m.activityEntries = entries
+	m.activityCursor = 0
+}
+
+// activityAt maps a cursor position to an entry; newest entries come first
+func (m *model) activityAt(cursor int) ActivityEntry {
+	return m.activityEntries[len(m.activityEntries)-1-cursor]
+}
+
+func (m model) activityPageSize() int {
+	if m.height > 10 {
+		return m.height - 6
+	}
+	return 10
+}
+
+func (m model) handleActivityKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := len(m.activityEntries) - 1
+	switch msg.String() {
+	case "esc", "q", "@":
+		m.activityOpen = false
+		m.activityEntries = nil
+	case "up", "k":
+		m.activityCursor--
+	case "down", "j":
+		m.activityCursor++
+	case "pgup", "ctrl+u":
+		m.activityCursor -= m.activityPageSize()
+	case "pgdown", "ctrl+d":
+		m.activityCursor += m.activityPageSize()
+	case "g", "home":
+		m.activityCursor = 0
+	case "G", "end":
+		m.activityCursor = last
+	case "x":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return m, showStatus("❌ "+err.Error(), "196")
+		}
+		path := filepath.Join(home, fmt.Sprintf("lif-activity-%s.csv", time.Now().Format("2006-01-02")))
+		file, err := os.Create(path)
+		if err != nil {
+			return m, showStatus("❌ "+err.Error(), "196")
+		}
+		err = exportActivity(file, m.activityEntries, false)
+		file.Close()
+		if err != nil {
+			return m, showStatus("❌ "+err.Error(), "196")
+		}
+		return m, showStatus("📤 Exported activity to "+path, "82")
+	}
+	if m.activityCursor > last {
+		m.activityCursor = last
+	}
+	if m.activityCursor < 0 {
+		m.activityCursor = 0
+	}
+	return m, nil
+}
+
+func (m model) activityView() string {
+	header := headerStyle.Render(fmt.Sprintf("📜 Activity (%d)", len(m.activityEntries)))
+
+	visible := len(m.activityEntries)
+	if visible > m.activityPageSize() {
+		visible = m.activityPageSize()
+	}
+	start := 0
+	if m.activityCursor >= visible {
+		start = m.activityCursor - visible + 1
+	}
+	end := start + visible
+	if end > len(m.activityEntries) {
+		end = len(m.activityEntries)
+	}
+
+	lines := []string{}
+	for i := start; i < end; i++ {
+		line := formatActivity(m.activityAt(i))
+		if i == m.activityCursor {
+			line = activeTabStyle.Render(">") + " " + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	footer := keyStyle.Render("↑↓/pgup/pgdn") + ": " + actionStyle.Render("scroll") + bulletStyle.Render(" • ") +
+		keyStyle.Render("g/G") + ": " + actionStyle.Render("newest/oldest") + bulletStyle.Render(" • ") +
+		keyStyle.Render("x") + ": " + actionStyle.Render("export CSV") + bulletStyle.Render(" • ") +
+		keyStyle.Render("esc") + ": " + actionStyle.Render("close")
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		header,
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		footer,
+	)
+}
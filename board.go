@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Kanban board view of the Rolling tab
+var boardColumns = []string{"backlog", "next", "doing", "done"}
+
+var boardColumnTitles = map[string]string{
+	"backlog": "Backlog",
+	"next":    "Next",
+	"doing":   "Doing",
+	"done":    "Done",
+}
+
+var (
+	cardStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(0, 1)
+	selectedCardStyle = cardStyle.BorderForeground(lipgloss.Color("57"))
+)
+
+// todoColumn returns the board column of a todo. Done todos always sit in the
+// done column; todos without a column start in the backlog.
+func todoColumn(todo RollingTodo) string {
+	if todo.Done {
+		return "done"
+	}
+	switch todo.Column {
+	case "next", "doing":
+		return todo.Column
+	}
+	return "backlog"
+}
+
+// setTodoColumn moves a todo to a board column, keeping Done in sync
+func setTodoColumn(todo *RollingTodo, column string) {
+	todo.Column = column
+	if column == "done" && !todo.Done {
+		todo.Done = true
+		todo.CompletedAt = time.Now()
+	} else if column != "done" && todo.Done {
+		todo.Done = false
+		todo.CompletedAt = time.Time{}
+	}
+}
+
+// boardCards lists the data indices of the todos in each column, in the same
+// order and with the same filters as the Rolling table.
+func (m *model) boardCards() [][]int {
+	cards := make([][]int, len(boardColumns))
+	for row, idx := range m.rowIndex[1] {
+		if m.rowSubtask[row] != -1 {
+			continue
+		}
+		column := todoColumn(m.data.RollingTodos[idx])
+		for c, name := range boardColumns {
+			if name == column {
+				cards[c] = append(cards[c], idx)
+			}
+		}
+	}
+	return cards
+}
+
+// syncBoardCursor clamps the board cursor and points the Rolling table cursor
+// at the selected card, so edit/delete/toggle work the same in both views.
+func (m *model) syncBoardCursor() {
+	cards := m.boardCards()
+	if m.boardCol < 0 {
+		m.boardCol = 0
+	}
+	if m.boardCol >= len(boardColumns) {
+		m.boardCol = len(boardColumns) - 1
+	}
+	if m.boardRow >= len(cards[m.boardCol]) {
+		m.boardRow = len(cards[m.boardCol]) - 1
+	}
+	if m.boardRow < 0 {
+		m.boardRow = 0
+	}
+	if len(cards[m.boardCol]) == 0 {
+		return
+	}
+
+	selected := cards[m.boardCol][m.boardRow]
+	for row, idx := range m.rowIndex[1] {
+		if idx == selected && m.rowSubtask[row] == -1 {
+			m.tables[1].SetCursor(row)
+			return
+		}
+	}
+}
+
+// focusBoardCard moves the board cursor onto the todo with the given ID
+func (m *model) focusBoardCard(id int) {
+	for c, column := range m.boardCards() {
+		for r, idx := range column {
+			if m.data.RollingTodos[idx].ID == id {
+				m.boardCol, m.boardRow = c, r
+				m.syncBoardCursor()
+				return
+			}
+		}
+	}
+}
+
+func (m *model) moveBoardCard(delta int) {
+	cards := m.boardCards()
+	if len(cards[m.boardCol]) == 0 {
+		return
+	}
+	target := m.boardCol + delta
+	if target < 0 || target >= len(boardColumns) {
+		return
+	}
+
+	todo := &m.data.RollingTodos[cards[m.boardCol][m.boardRow]]
+	setTodoColumn(todo, boardColumns[target])
+	id := todo.ID
+
+	m.tables[1].SetRows(m.rollingRows())
+	m.tables[4].SetRows(m.projectRows())
+	m.focusBoardCard(id)
+	m.logActivity("moved", "todo", id, fmt.Sprintf("%s → %s", todoByID(m.data.RollingTodos, id).Task, boardColumnTitles[boardColumns[target]]))
+	m.save("move card")
+
+	todo = todoByID(m.data.RollingTodos, id)
+	m.statusMsg = fmt.Sprintf("➡️ %s → %s", todo.Task, boardColumnTitles[boardColumns[target]])
+	m.statusColor = "82"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
+
+func (m *model) handleBoardKey(key string) bool {
+	switch key {
+	case "h":
+		m.boardCol--
+	case "l":
+		m.boardCol++
+	case "k", "up":
+		m.boardRow--
+	case "j", "down":
+		m.boardRow++
+	case "<", "H", "shift+left":
+		m.moveBoardCard(-1)
+		return true
+	case ">", "L", "shift+right":
+		m.moveBoardCard(1)
+		return true
+	default:
+		return false
+	}
+	m.syncBoardCursor()
+	return true
+}
+
+func (m *model) boardView() string {
+	cards := m.boardCards()
+
+	width := m.width
+	if width == 0 {
+		width = 120
+	}
+	colWidth := (width - 2) / len(boardColumns)
+	if colWidth < 20 {
+		colWidth = 20
+	}
+
+	// Each card is roughly 4 lines tall; show as many as fit
+	maxCards := (m.height - 12) / 4
+	if maxCards < 2 {
+		maxCards = 2
+	}
+
+	columns := []string{}
+	for c, name := range boardColumns {
+		title := fmt.Sprintf("%s (%d)", boardColumnTitles[name], len(cards[c]))
+		titleStyle := headerStyle
+		if c == m.boardCol {
+			titleStyle = activeTabStyle
+		}
+		parts := []string{titleStyle.Render(title)}
+
+		start := 0
+		if c == m.boardCol && m.boardRow >= maxCards {
+			start = m.boardRow - maxCards + 1
+		}
+		end := start + maxCards
+		if end > len(cards[c]) {
+			end = len(cards[c])
+		}
+		if start > 0 {
+			parts = append(parts, bulletStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+		}
+		for r := start; r < end; r++ {
+			parts = append(parts, m.boardCard(m.data.RollingTodos[cards[c][r]], colWidth-2, c == m.boardCol && r == m.boardRow))
+		}
+		if end < len(cards[c]) {
+			parts = append(parts, bulletStyle.Render(fmt.Sprintf("  ↓ %d more", len(cards[c])-end)))
+		}
+
+		columns = append(columns, lipgloss.NewStyle().Width(colWidth).Render(strings.Join(parts, "\n")))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+}
+
+func (m *model) boardCard(todo RollingTodo, width int, selected bool) string {
+	style := cardStyle
+	if selected {
+		style = selectedCardStyle
+	}
+	style = style.Width(width)
+
+	task := todo.Task
+	if todo.Done {
+		task = "✓ " + task
+	}
+	if isBlocked(todo, m.data.RollingTodos) {
+		task = blockedStyle.Render("⛔ " + task)
+	}
+
+	var priority string
+	switch todo.Priority {
+	case "HIGH":
+		priority = priorityHighStyle.Render("HIGH")
+	case "LOW":
+		priority = priorityLowStyle.Render("LOW")
+	default:
+		priority = priorityMedStyle.Render("MEDIUM")
+	}
+	meta := []string{bulletStyle.Render(fmt.Sprintf("#%d", todo.ID)), priority}
+	if name := projectName(m.data.Projects, todo.ProjectID); name != "" {
+		meta = append(meta, bulletStyle.Render(name))
+	}
+	if len(todo.Subtasks) > 0 {
+		meta = append(meta, bulletStyle.Render(strings.TrimSuffix(todoProgress(todo), " ✓")))
+	}
+
+	return style.Render(task + "\n" + strings.Join(meta, bulletStyle.Render(" · ")))
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package main
+
+import "time"
+
+// bootClock has no portable equivalent outside Linux, see runningTime
+func bootClock() time.Duration {
+	return 0
+}
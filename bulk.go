@@ -0,0 +1,277 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// Multi-select and bulk actions. Marks are data indices on the tab they were
+// made on and are dropped when switching tabs.
+
+func (m *model) markCell(tab, idx int, cell string) string {
+	if m.markedTab == tab && m.marked[idx] {
+		return "● " + cell
+	}
+	return cell
+}
+
+// markedIndices returns the marked data indices, highest first so they can
+// be deleted in order
+func (m *model) markedIndices() []int {
+	indices := []int{}
+	for idx, marked := range m.marked {
+		if marked {
+			indices = append(indices, idx)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(indices)))
+	return indices
+}
+
+func (m *model) clearMarks() {
+	tab := m.markedTab
+	m.marked = map[int]bool{}
+	m.refreshTab(tab)
+}
+
+// toggleMark marks or unmarks the row under the cursor. Group headers and
+// subtask rows can't be marked.
+func (m *model) toggleMark() {
+	tableIdx := m.activeTab - 2
+	idx := m.selectedIndex(tableIdx)
+	if idx == -1 || (m.activeTab == 3 && m.selectedSubtask() != -1) {
+		return
+	}
+	if m.markedTab != m.activeTab {
+		m.marked = map[int]bool{}
+		m.markedTab = m.activeTab
+	}
+	if m.marked[idx] {
+		delete(m.marked, idx)
+	} else {
+		m.marked[idx] = true
+	}
+	m.refreshTab(m.activeTab)
+}
+
+// markAll marks every visible row, or clears the marks when all of them
+// already are
+func (m *model) markAll() {
+	tableIdx := m.activeTab - 2
+	if m.markedTab != m.activeTab {
+		m.marked = map[int]bool{}
+		m.markedTab = m.activeTab
+	}
+	visible := []int{}
+	for row, idx := range m.rowIndex[tableIdx] {
+		if idx == -1 || (m.activeTab == 3 && m.rowSubtask[row] != -1) {
+			continue
+		}
+		visible = append(visible, idx)
+	}
+
+	allMarked := true
+	for _, idx := range visible {
+		if !m.marked[idx] {
+			allMarked = false
+		}
+	}
+	m.marked = map[int]bool{}
+	if !allMarked {
+		for _, idx := range visible {
+			m.marked[idx] = true
+		}
+	}
+	m.refreshTab(m.activeTab)
+}
+
+func (m *model) confirmDeleteMarked() {
+	m.confirmDelete = true
+	m.deleteTarget = fmt.Sprintf("%d selected items", len(m.markedIndices()))
+}
+
+func (m *model) deleteMarked() {
+	indices := m.markedIndices()
+	for _, idx := range indices {
+		m.logItem("deleted", m.activeTab, idx)
+		m.trashItem(m.activeTab, idx)
+		switch m.activeTab {
+		case 2: // Dailies
+			m.data.Dailies = append(m.data.Dailies[:idx], m.data.Dailies[idx+1:]...)
+		case 3: // Rolling Todos
+			removedID := m.data.RollingTodos[idx].ID
+			m.data.RollingTodos = append(m.data.RollingTodos[:idx], m.data.RollingTodos[idx+1:]...)
+			removeDependency(m.data.RollingTodos, removedID)
+		case 4: // Reminders
+			m.data.Reminders = append(m.data.Reminders[:idx], m.data.Reminders[idx+1:]...)
+		case 5: // Glossary
+			m.data.Glossary = append(m.data.Glossary[:idx], m.data.Glossary[idx+1:]...)
+		case 6: // Projects
+			removedID := m.data.Projects[idx].ID
+			m.data.Projects = append(m.data.Projects[:idx], m.data.Projects[idx+1:]...)
+			for i := range m.data.RollingTodos {
+				if m.data.RollingTodos[i].ProjectID == removedID {
+					m.data.RollingTodos[i].ProjectID = 0
+				}
+			}
+		}
+	}
+
+	m.marked = map[int]bool{}
+	m.refreshTables()
+	m.save(fmt.Sprintf("delete of %d items", len(indices)))
+	m.statusMsg = fmt.Sprintf("🗑️ Deleted %d items", len(indices))
+	m.statusColor = "196"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
+
+// markDone completes every marked daily or rolling todo
+func (m *model) markDone() {
+	indices := m.markedIndices()
+	if len(indices) == 0 || (m.activeTab != 2 && m.activeTab != 3) {
+		return
+	}
+	for _, idx := range indices {
+		if m.activeTab == 2 {
+			m.data.Dailies[idx].Status = "DONE"
+			m.data.Dailies[idx].LastCompleted = time.Now()
+			m.logItem("completed", 2, idx)
+			continue
+		}
+		todo := &m.data.RollingTodos[idx]
+		if !todo.Done {
+			todo.Done = true
+			todo.CompletedAt = time.Now()
+			m.logItem("completed", 3, idx)
+		}
+		for i := range todo.Subtasks {
+			todo.Subtasks[i].Done = true
+		}
+	}
+
+	m.marked = map[int]bool{}
+	m.refreshTables()
+	m.save(fmt.Sprintf("mark done of %d items", len(indices)))
+	m.statusMsg = fmt.Sprintf("✅ Marked %d items as done", len(indices))
+	m.statusColor = "82"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
+
+// bulkReminderAction starts or pauses every marked reminder
+func (m *model) bulkReminderAction(action string) {
+	indices := m.markedIndices()
+	changed := 0
+	for _, idx := range indices {
+		reminder := &m.data.Reminders[idx]
+		before := reminder.Status
+		applyReminderAction(reminder, action)
+		if reminder.Status != before {
+			changed++
+			m.logItem(reminderEvents[action], 4, idx)
+		}
+	}
+
+	m.marked = map[int]bool{}
+	m.tables[2].SetRows(m.reminderRows())
+	m.save(fmt.Sprintf("%s of %d reminders", action, len(indices)))
+	verb := "Started"
+	if action == "pause" {
+		verb = "Paused"
+	}
+	m.statusMsg = fmt.Sprintf("%s %d of %d reminders", verb, changed, len(indices))
+	m.statusColor = "82"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
+
+// startBulkEdit opens a form whose non-empty fields are applied to every
+// marked item
+func (m *model) startBulkEdit() {
+	if m.activeTab != 2 && m.activeTab != 3 {
+		m.statusMsg = "Bulk edit works on Dailies and Rolling Todos"
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+		return
+	}
+
+	m.editing = true
+	m.bulkEdit = true
+	m.editingTab = m.activeTab
+	m.editingRow = -1
+	m.editingField = 0
+	m.editingSubtask = false
+
+	fields := 2
+	if m.activeTab == 3 {
+		fields = 3
+	}
+	m.inputs = make([]textinput.Model, fields)
+	for i := range m.inputs {
+		m.inputs[i] = textinput.New()
+		m.inputs[i].Placeholder = "(unchanged)"
+	}
+	m.inputs[0].Focus()
+}
+
+func (m *model) saveBulkEdit() error {
+	priority := strings.TrimSpace(m.inputs[0].Value())
+	category := strings.TrimSpace(m.inputs[1].Value())
+	projectID := -1
+	if m.editingTab == 3 {
+		// "-" takes the todos out of their project
+		if name := strings.TrimSpace(m.inputs[2].Value()); name == "-" {
+			projectID = 0
+		} else if name != "" {
+			var err error
+			if projectID, err = findProjectID(m.data.Projects, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	indices := m.markedIndices()
+	changed := 0
+	for _, idx := range indices {
+		if m.editingTab == 2 {
+			daily := &m.data.Dailies[idx]
+			old := *daily
+			if priority != "" {
+				daily.Priority = normalizePriority(priority)
+			}
+			if category != "" {
+				daily.Category = normalizeText(category)
+			}
+			if daily.Priority != old.Priority || daily.Category != old.Category {
+				m.logItem("edited", 2, idx)
+				changed++
+			}
+			continue
+		}
+		todo := &m.data.RollingTodos[idx]
+		old := *todo
+		if priority != "" {
+			todo.Priority = normalizePriority(priority)
+		}
+		if category != "" {
+			todo.Category = normalizeText(category)
+		}
+		if projectID != -1 {
+			todo.ProjectID = projectID
+		}
+		if todo.Priority != old.Priority || todo.Category != old.Category || todo.ProjectID != old.ProjectID {
+			m.logItem("edited", 3, idx)
+			changed++
+		}
+	}
+
+	m.bulkEdit = false
+	m.marked = map[int]bool{}
+	m.refreshTables()
+	if changed > 0 {
+		m.save(fmt.Sprintf("bulk edit of %d items", changed))
+	}
+	return nil
+}
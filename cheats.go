@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// Importers for local cheat sources (tldr-pages and navi cheat files)
+
+var tldrPlaceholder = regexp.MustCompile(`\{\{(.*?)\}\}`)
+
+func defaultTldrDirs() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	cacheDir, _ := os.UserCacheDir()
+
+	return existingDirs([]string{
+		os.Getenv("TLDR_CACHE_DIR"),
+		filepath.Join(cacheDir, "tealdeer", "tldr-pages"),
+		filepath.Join(cacheDir, "tldr"),
+		filepath.Join(home, ".tldr", "cache", "pages"),
+		filepath.Join(home, ".local", "share", "tldr"),
+	})
+}
+
+// Platform page directories in tldr-pages by GOOS; pages in "common" apply
+// everywhere
+var tldrPlatforms = map[string]string{
+	"linux":   "linux",
+	"darwin":  "osx",
+	"windows": "windows",
+	"android": "android",
+	"freebsd": "freebsd",
+	"netbsd":  "netbsd",
+	"openbsd": "openbsd",
+	"solaris": "sunos",
+}
+
+// defaultTldrFiles lists the pages in the tldr caches that apply to this
+// platform and language. The caches hold every page in every language, far
+// more than anyone wants in their glossary.
+func defaultTldrFiles() ([]string, error) {
+	files, err := collectFiles(defaultTldrDirs(), ".md")
+	if err != nil {
+		return nil, err
+	}
+	return tldrPagesFor(files, runtime.GOOS, userLocale()), nil
+}
+
+// userLocale is the locale messages are shown in, like "de_DE.UTF-8"
+func userLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// tldrLanguages lists the tldr languages for locale, most preferred first:
+// "pt_BR" and "pt" for pt_BR.UTF-8, with English last
+func tldrLanguages(locale string) []string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	languages := []string{}
+	if locale != "" && locale != "C" && locale != "POSIX" {
+		languages = append(languages, locale)
+		if lang, _, ok := strings.Cut(locale, "_"); ok {
+			languages = append(languages, lang)
+		}
+	}
+	if !slices.Contains(languages, "en") {
+		languages = append(languages, "en")
+	}
+	return languages
+}
+
+// tldrPagesFor keeps the common and goos pages among files laid out as
+// pages[.lang]/platform/page.md, one file per page in the best language of
+// locale there is. Translations have their own placeholders and
+// descriptions, so importing more than one language would add every
+// example twice.
+func tldrPagesFor(files []string, goos, locale string) []string {
+	languages := tldrLanguages(locale)
+	known := map[string]bool{"common": true}
+	for _, platform := range tldrPlatforms {
+		known[platform] = true
+	}
+
+	type choice struct {
+		file string
+		rank int
+	}
+	best := map[string]choice{}
+	pages := []string{}
+	for _, file := range files {
+		platform := filepath.Base(filepath.Dir(file))
+		if known[platform] && platform != "common" && platform != tldrPlatforms[goos] {
+			continue
+		}
+		lang := "en"
+		if dir := filepath.Base(filepath.Dir(filepath.Dir(file))); strings.HasPrefix(dir, "pages.") {
+			lang = strings.TrimPrefix(dir, "pages.")
+		}
+		rank := slices.Index(languages, lang)
+		if rank == -1 {
+			continue
+		}
+		page := platform + "/" + filepath.Base(file)
+		current, seen := best[page]
+		if !seen {
+			pages = append(pages, page)
+		}
+		if !seen || rank < current.rank {
+			best[page] = choice{file, rank}
+		}
+	}
+
+	picked := []string{}
+	for _, page := range pages {
+		picked = append(picked, best[page].file)
+	}
+	return picked
+}
+
+func defaultNaviFiles() ([]string, error) {
+	return collectFiles(defaultNaviDirs(), ".cheat")
+}
+
+func defaultNaviDirs() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		dataDir = filepath.Join(home, ".local", "share")
+	}
+	if runtime.GOOS == "darwin" {
+		dataDir = filepath.Join(home, "Library", "Application Support")
+	}
+
+	return existingDirs([]string{
+		os.Getenv("NAVI_PATH"),
+		filepath.Join(dataDir, "navi", "cheats"),
+	})
+}
+
+func existingDirs(dirs []string) []string {
+	found := []string{}
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			found = append(found, dir)
+		}
+	}
+	return found
+}
+
+// collectFiles expands directories into the files below them with the given
+// extension; plain file arguments are kept as they are.
+func collectFiles(paths []string, ext string) ([]string, error) {
+	files := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(p, ext) {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// parseTldrPage maps a tldr-pages markdown page onto glossary items: one item
+// per example, with Lang from the page name, Usage from the page description
+// and Meaning from the example description.
+func parseTldrPage(name string, data []byte) []GlossaryItem {
+	items := []GlossaryItem{}
+	description := []string{}
+	example := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "# "):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		case strings.HasPrefix(line, "> "):
+			text := strings.TrimSpace(strings.TrimPrefix(line, "> "))
+			if !strings.HasPrefix(text, "More information:") && !strings.HasPrefix(text, "See also:") {
+				description = append(description, text)
+			}
+		case strings.HasPrefix(line, "- "):
+			example = strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(line, "- ")), ":")
+		case strings.HasPrefix(line, "`") && strings.HasSuffix(line, "`") && len(line) > 1:
+			command := strings.Trim(line, "`")
+			command = tldrPlaceholder.ReplaceAllString(command, "<$1>")
+			items = append(items, GlossaryItem{
+				Lang:    name,
+				Command: command,
+				Usage:   strings.Join(description, " "),
+				Meaning: example,
+			})
+			example = ""
+		}
+	}
+	return items
+}
+
+// parseNaviCheat maps a navi .cheat file onto glossary items: Lang from the
+// first "%" tag, Meaning from the "#" description and the command lines below
+// it as Command.
+func parseNaviCheat(name string, data []byte) []GlossaryItem {
+	items := []GlossaryItem{}
+	lang := name
+	description := ""
+	command := []string{}
+
+	flush := func() {
+		if len(command) > 0 {
+			items = append(items, GlossaryItem{
+				Lang:    lang,
+				Command: strings.Join(command, "\n"),
+				Meaning: description,
+			})
+		}
+		command = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "%"):
+			flush()
+			tags := strings.Split(strings.TrimSpace(strings.TrimPrefix(trimmed, "%")), ",")
+			if tag := strings.TrimSpace(tags[0]); tag != "" {
+				lang = tag
+			}
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+			description = strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+		case strings.HasPrefix(trimmed, "$"), strings.HasPrefix(trimmed, ";"), strings.HasPrefix(trimmed, "@"):
+			// Variable definitions, comments and extends lines carry no command
+			flush()
+		default:
+			command = append(command, line)
+		}
+	}
+	flush()
+	return items
+}
+
+func importCheatFiles(files []string, parse func(name string, data []byte) []GlossaryItem) ([]GlossaryItem, error) {
+	items := []GlossaryItem{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		items = append(items, parse(name, data)...)
+	}
+	return items, nil
+}
+
+// mergeGlossary appends items whose command is not already in the glossary
+// and returns how many were added.
+func mergeGlossary(data *AppData, items []GlossaryItem) int {
+	known := map[string]bool{}
+	for _, item := range data.Glossary {
+		known[strings.ToLower(normalizeText(item.Command))] = true
+	}
+
+	added := 0
+	for _, item := range items {
+		key := strings.ToLower(normalizeText(item.Command))
+		if key == "" || known[key] {
+			continue
+		}
+		known[key] = true
+
+		item.ID = nextGlossaryID(data.Glossary)
+		item.Lang = normalizeText(item.Lang)
+		item.Command = normalizeText(item.Command)
+		item.Usage = normalizeText(item.Usage)
+		item.Example = normalizeText(item.Example)
+		item.Meaning = normalizeText(item.Meaning)
+		data.Glossary = append(data.Glossary, item)
+		added++
+	}
+	return added
+}
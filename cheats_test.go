@@ -0,0 +1,42 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestTldrPagesFor(t *testing.T) {
+	files := []string{}
+	for _, page := range []string{
+		"pages/common/tar.md",
+		"pages/linux/apt.md",
+		"pages/osx/brew.md",
+		"pages/windows/dir.md",
+		"pages.de/common/tar.md",
+		"pages.de/linux/apt.md",
+		"pages.fr/common/tar.md",
+		"pages.pt_BR/common/tar.md",
+	} {
+		files = append(files, filepath.Join("cache", filepath.FromSlash(page)))
+	}
+
+	for _, tc := range []struct {
+		goos, locale string
+		want         []string
+	}{
+		{"linux", "", []string{"pages/common/tar.md", "pages/linux/apt.md"}},
+		{"darwin", "C.UTF-8", []string{"pages/common/tar.md", "pages/osx/brew.md"}},
+		{"linux", "de_DE.UTF-8", []string{"pages.de/common/tar.md", "pages.de/linux/apt.md"}},
+		{"windows", "pt_BR.UTF-8", []string{"pages.pt_BR/common/tar.md", "pages/windows/dir.md"}},
+		{"darwin", "fr_FR.UTF-8", []string{"pages.fr/common/tar.md", "pages/osx/brew.md"}},
+	} {
+		want := []string{}
+		for _, page := range tc.want {
+			want = append(want, filepath.Join("cache", filepath.FromSlash(page)))
+		}
+		if got := tldrPagesFor(files, tc.goos, tc.locale); !slices.Equal(got, want) {
+			t.Errorf("%s %q: got %q, want %q", tc.goos, tc.locale, got, want)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const cliUsage = `usage:
+  lif                                   start the TUI
+  lif glossary import-history [file...] import glossary entries from shell history
+  lif glossary import-tldr [path...]    import examples from tldr-pages markdown files
+  lif glossary import-navi [path...]    import commands from navi .cheat files
+  lif activity [text]                   print the activity journal, optionally filtered
+  lif activity export [file]            export the journal as CSV (or JSON for *.json)
+  lif laps [export [file]]              print the stopwatch laps, or export them as CSV
+  lif notify test [backend...]          send a test notification (default backends if none given)
+  lif ack [id|text]                     acknowledge repeating reminders (all of them without arguments)`
+
+func runCLI(args []string) error {
+	switch args[0] {
+	case "glossary":
+		if len(args) < 2 {
+			return fmt.Errorf("missing glossary command\n%s", cliUsage)
+		}
+		switch args[1] {
+		case "import-history":
+			return cliImportHistory(args[2:])
+		case "import-tldr":
+			return cliImportCheats(args[2:], defaultTldrFiles, ".md", parseTldrPage)
+		case "import-navi":
+			return cliImportCheats(args[2:], defaultNaviFiles, ".cheat", parseNaviCheat)
+		}
+		return fmt.Errorf("unknown glossary command %q\n%s", args[1], cliUsage)
+	case "activity":
+		if len(args) > 1 && args[1] == "export" {
+			return cliExportActivity(args[2:])
+		}
+		return cliActivity(strings.Join(args[1:], " "))
+	case "notify":
+		if len(args) < 2 || args[1] != "test" {
+			return fmt.Errorf("unknown notify command\n%s", cliUsage)
+		}
+		return cliNotifyTest(args[2:])
+	case "ack":
+		return cliAck(strings.Join(args[1:], " "))
+	case "laps":
+		if len(args) > 1 && args[1] == "export" {
+			return cliExportLaps(args[2:])
+		}
+		return cliLaps()
+	case "help", "-h", "--help":
+		fmt.Println(cliUsage)
+		return nil
+	}
+	return fmt.Errorf("unknown command %q\n%s", args[0], cliUsage)
+}
+
+func cliImportHistory(files []string) error {
+	data := loadData()
+
+	candidates, err := loadHistoryCandidates(files, data.Glossary, 50)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		fmt.Println("No new commands found in shell history")
+		return nil
+	}
+
+	for i, candidate := range candidates {
+		fmt.Printf("%3d. [%s] %s (x%d)\n", i+1, inferLang(candidate.Command), oneLine(candidate.Command), candidate.Count)
+	}
+	fmt.Print("\nSelect entries to import (e.g. 1,3,5-7, 'a' for all, empty to cancel): ")
+
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	selected, err := parseSelection(line, len(candidates))
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		fmt.Println("Nothing imported")
+		return nil
+	}
+
+	items := []GlossaryItem{}
+	for _, idx := range selected {
+		items = append(items, historyGlossaryItem(candidates[idx], 0))
+	}
+	if err := appendImports(items...); err != nil {
+		return err
+	}
+	appendActivity(ActivityEntry{Time: time.Now(), Event: "imported", Kind: "glossary", Name: fmt.Sprintf("%d entries from shell history", len(selected))})
+	fmt.Printf("Imported %d glossary entries; lif adds them to the glossary when it next runs\n", len(selected))
+	return nil
+}
+
+// cliImportCheats imports the cheat files under paths, or the default ones
+// when no paths are given
+func cliImportCheats(paths []string, defaults func() ([]string, error), ext string, parse func(string, []byte) []GlossaryItem) error {
+	var files []string
+	var err error
+	if len(paths) == 0 {
+		files, err = defaults()
+	} else {
+		files, err = collectFiles(paths, ext)
+	}
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no %s files found, pass a file or directory to import", ext)
+	}
+	items, err := importCheatFiles(files, parse)
+	if err != nil {
+		return err
+	}
+
+	// Merged into a copy only to leave out the duplicates; the TUI adds
+	// them, see imports.go
+	data := loadData()
+	known := len(data.Glossary)
+	added := mergeGlossary(&data, items)
+	if added > 0 {
+		if err := appendImports(data.Glossary[known:]...); err != nil {
+			return err
+		}
+		appendActivity(ActivityEntry{Time: time.Now(), Event: "imported", Kind: "glossary", Name: fmt.Sprintf("%d entries from %s files", added, ext)})
+	}
+	fmt.Printf("Imported %d glossary entries from %d files (%d duplicates skipped); lif adds them to the glossary when it next runs\n", added, len(files), len(items)-added)
+	return nil
+}
+
+// cliActivity prints the journal, oldest first, keeping only entries that
+// mention filter when one is given.
+func cliActivity(filter string) error {
+	entries, err := loadActivity()
+	if err != nil {
+		return err
+	}
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	for _, entry := range entries {
+		line := formatActivity(entry)
+		if filter == "" || strings.Contains(strings.ToLower(line), filter) {
+			fmt.Println(line)
+		}
+	}
+	return nil
+}
+
+func cliExportActivity(args []string) error {
+	entries, err := loadActivity()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return exportActivity(os.Stdout, entries, false)
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := exportActivity(file, entries, strings.HasSuffix(args[0], ".json")); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d activity entries to %s\n", len(entries), args[0])
+	return nil
+}
+
+func cliLaps() error {
+	data := loadData()
+	for i, lap := range data.Stopwatch.Laps {
+		fmt.Printf("Lap %-3d %-20s +%s  %s\n", i+1, lap.Label, formatLap(lap.Split), formatLap(lap.Total))
+	}
+	return nil
+}
+
+func cliExportLaps(args []string) error {
+	laps := loadData().Stopwatch.Laps
+	if len(args) == 0 {
+		return exportLaps(os.Stdout, laps)
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := exportLaps(file, laps); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d laps to %s\n", len(laps), args[0])
+	return nil
+}
+
+// cliNotifyTest sends a notification through each backend in turn and
+// reports which ones failed
+func cliNotifyTest(backends []string) error {
+	settings := loadData().Notify
+	if len(backends) == 0 {
+		backends = settings.defaults()
+	}
+	failed := 0
+	for _, name := range backends {
+		notifier, err := newNotifier(name, settings, nil)
+		if err == nil {
+			err = notifier.Notify(Notification{Title: "lif", Message: "Test notification from lif"})
+		}
+		if err != nil {
+			fmt.Printf("%-8s failed: %v\n", name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%-8s ok\n", name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d backends failed", failed, len(backends))
+	}
+	return nil
+}
+
+// cliAck acknowledges repeating reminders matching an ID or text. The
+// acknowledgement also goes to acks.jsonl so a running TUI picks it up.
+func cliAck(filter string) error {
+	data := loadData()
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	now := time.Now()
+	acks := []Acknowledgement{}
+	entries := []ActivityEntry{}
+	for i := range data.Reminders {
+		reminder := &data.Reminders[i]
+		if !reminder.nagging() {
+			continue
+		}
+		if filter != "" && strconv.Itoa(reminder.ID) != filter && !strings.Contains(strings.ToLower(reminder.Reminder), filter) {
+			continue
+		}
+		reminder.AcknowledgedAt = now
+		acks = append(acks, Acknowledgement{ID: reminder.ID, TargetTime: reminder.TargetTime, Time: now})
+		entries = append(entries, ActivityEntry{Time: now, Event: "acknowledged", Kind: "reminder", ID: reminder.ID, Name: reminder.Reminder})
+		fmt.Printf("Acknowledged #%d %s\n", reminder.ID, reminder.Reminder)
+	}
+	if len(acks) == 0 {
+		fmt.Println("No repeating reminders to acknowledge")
+		return nil
+	}
+
+	if err := appendAcknowledgements(acks...); err != nil {
+		return err
+	}
+	saveData(data)
+	return appendActivity(entries...)
+}
+
+// parseSelection turns "1,3,5-7" (1-based) or "a" into 0-based indices.
+func parseSelection(input string, max int) ([]int, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, nil
+	}
+	if input == "a" || input == "all" {
+		all := make([]int, max)
+		for i := range all {
+			all[i] = i
+		}
+		return all, nil
+	}
+
+	seen := map[int]bool{}
+	selected := []int{}
+	for _, part := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		start, end := part, part
+		if idx := strings.Index(part, "-"); idx != -1 {
+			start, end = part[:idx], part[idx+1:]
+		}
+		from, err := strconv.Atoi(start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		to, err := strconv.Atoi(end)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		if from < 1 || to > max || from > to {
+			return nil, fmt.Errorf("selection %q out of range 1-%d", part, max)
+		}
+		for i := from - 1; i < to; i++ {
+			if !seen[i] {
+				seen[i] = true
+				selected = append(selected, i)
+			}
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Countdown bookkeeping. A running countdown keeps the time it has used up in
+// Elapsed rather than trusting a wall-clock target: each tick adds the time
+// since the previous one, and TargetTime is only where the remainder lands
+// on the wall clock. Comparing the clocks between ticks shows when the clock
+// was changed or the computer slept. The clock being changed doesn't give a
+// countdown extra time or use it up, and time spent suspended counts, since
+// it passed for the user (see runningTime for where the two can't be told
+// apart). While lif is closed the countdown runs on by the wall clock, from
+// CountedAt.
+
+// A suspend or clock change longer than this between two ticks is shown in
+// the status line
+const clockJumpThreshold = 5 * time.Second
+
+// Countdown is the running time bookkeeping shared by countdown reminders
+// and pomodoro phases
+type Countdown struct {
+	// Countdown length and the running time used up as of CountedAt
+	Duration  time.Duration `json:"duration,omitempty"`
+	Elapsed   time.Duration `json:"elapsed,omitempty"`
+	CountedAt time.Time     `json:"counted_at,omitempty"`
+}
+
+func (c *Countdown) start(d time.Duration, now time.Time) {
+	c.Duration = d
+	c.Elapsed = 0
+	c.CountedAt = now.Round(0)
+}
+
+// left is what remains of the countdown, never below zero
+func (c *Countdown) left() time.Duration {
+	if c.Elapsed >= c.Duration {
+		return 0
+	}
+	return c.Duration - c.Elapsed
+}
+
+// count adds running time and returns where the remainder now lands on the
+// wall clock. It isn't clamped, so a countdown that ran out keeps when it was
+// due.
+func (c *Countdown) count(running time.Duration, now time.Time) time.Time {
+	c.Elapsed += running
+	c.CountedAt = now.Round(0)
+	return c.CountedAt.Add(c.Duration - c.Elapsed)
+}
+
+// resume carries on with remaining left to go
+func (c *Countdown) resume(remaining time.Duration, now time.Time) {
+	c.Elapsed = c.Duration - remaining
+	c.CountedAt = now.Round(0)
+}
+
+// startCountdown starts the reminder counting down d from now
+func (r *Reminder) startCountdown(d time.Duration, now time.Time) {
+	r.IsCountdown = true
+	r.start(d, now)
+	r.TargetTime = now.Round(0).Add(d)
+}
+
+// counting reports whether the reminder is a countdown that is running
+func (r *Reminder) counting() bool {
+	return r.IsCountdown && r.Duration > 0 && r.Status == "active" && !r.Notified
+}
+
+func (r *Reminder) countdownRemaining() time.Duration {
+	return r.left()
+}
+
+// pauseCountdown stops counting and keeps what is left
+func (r *Reminder) pauseCountdown() {
+	r.PausedRemaining = r.left()
+}
+
+// resumeCountdown carries on from what was left when it was paused
+func (r *Reminder) resumeCountdown(now time.Time) {
+	r.resume(r.PausedRemaining, now)
+	r.TargetTime = now.Round(0).Add(r.PausedRemaining)
+}
+
+// addRunning counts running time towards the countdown and moves TargetTime
+// when it no longer matches
+func (r *Reminder) addRunning(running time.Duration, now time.Time) {
+	target := r.count(running, now)
+	if drift := target.Sub(r.TargetTime); drift > time.Second || drift < -time.Second {
+		// Lead alerts already sent stay sent for the moved target
+		if r.LeadTarget.Equal(r.TargetTime) {
+			r.LeadTarget = target
+		}
+		r.TargetTime = target
+	}
+}
+
+// catchUpCountdowns counts the wall-clock time since the countdowns were last
+// saved, for data loaded from disk or an undo snapshot. Countdowns saved
+// before Elapsed was tracked are converted from their target time.
+func catchUpCountdowns(data *AppData, now time.Time) {
+	for i := range data.Reminders {
+		reminder := &data.Reminders[i]
+		if reminder.IsCountdown && reminder.Duration == 0 && !reminder.TargetTime.IsZero() {
+			d, ok := countdownDuration(reminder.AlarmOrCountdown)
+			if !ok {
+				continue
+			}
+			remaining := reminder.TargetTime.Sub(now)
+			if reminder.Status == "paused" {
+				remaining = reminder.PausedRemaining
+			}
+			reminder.Duration = d
+			reminder.Elapsed = max(d-remaining, 0)
+			reminder.CountedAt = now.Round(0)
+		}
+		if !reminder.counting() {
+			continue
+		}
+		// A clock set back while lif was closed counts as no time
+		reminder.addRunning(max(now.Sub(reminder.CountedAt), 0), now)
+	}
+}
+
+// A tickClock is when a tick happened, on the wall and monotonic clocks and
+// on the boot clock, which also counts time spent suspended. Boot is 0 where
+// there is no boot clock.
+type tickClock struct {
+	Time time.Time
+	Boot time.Duration
+}
+
+func readTickClock(t time.Time) tickClock {
+	return tickClock{Time: t, Boot: bootClock()}
+}
+
+// runningTime is the time that passed between two ticks, split into the
+// part spent suspended and how far the wall clock was changed on top of it.
+// With a boot clock (Linux) the running time is the boot clock's, so setting
+// the clock forward or back counts as no time. Without one a suspend can't
+// be told apart from the clock being set forward: the wall clock moving
+// further than the monotonic one is taken for a suspend, so setting the
+// clock ahead uses up countdowns too. Setting it back never adds time.
+func runningTime(last, now tickClock) (running, suspended, changed time.Duration) {
+	monotonic := max(now.Time.Sub(last.Time), 0)
+	wall := now.Time.Round(0).Sub(last.Time.Round(0))
+	if last.Boot > 0 && now.Boot > 0 {
+		running = max(now.Boot-last.Boot, 0)
+		suspended = max(running-monotonic, 0)
+	} else {
+		running = max(monotonic, wall)
+		suspended = running - monotonic
+	}
+	return running, suspended, wall - running
+}
+
+// advanceCountdowns counts the time since the last tick towards running
+// countdowns and the pomodoro phase
+func (m *model) advanceCountdowns(last, now tickClock) {
+	running, suspended, changed := runningTime(last, now)
+	for i := range m.data.Reminders {
+		if m.data.Reminders[i].counting() {
+			m.data.Reminders[i].addRunning(running, now.Time)
+		}
+	}
+	if suspended > clockJumpThreshold || changed > clockJumpThreshold || changed < -clockJumpThreshold {
+		switch {
+		case changed > clockJumpThreshold || changed < -clockJumpThreshold:
+			m.statusMsg = fmt.Sprintf("⏱ Clock moved %s, countdowns kept their time", formatJump(changed))
+		case last.Boot > 0 && now.Boot > 0:
+			m.statusMsg = fmt.Sprintf("⏱ Asleep for %s, countdowns adjusted", formatDuration(suspended))
+		default:
+			m.statusMsg = fmt.Sprintf("⏱ Clock moved %s (suspend or clock change), countdowns adjusted", formatJump(suspended))
+		}
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(5 * time.Second)
+	}
+	if m.data.Pomodoro.counting() {
+		m.data.Pomodoro.TargetTime = m.data.Pomodoro.count(running, now.Time)
+		m.expirePomodoro("during a suspend or clock change")
+	}
+}
+
+func formatJump(d time.Duration) string {
+	if d < 0 {
+		return "back " + formatDuration(-d)
+	}
+	return "ahead " + formatDuration(d)
+}
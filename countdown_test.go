@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// Times built here have no monotonic reading, so only the running time and
+// the clock change are checked
+func TestRunningTime(t *testing.T) {
+	start := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	boot := 10 * time.Minute
+
+	for _, tc := range []struct {
+		name             string
+		last, now        tickClock
+		running, changed time.Duration
+	}{
+		{
+			"tick",
+			tickClock{start, boot}, tickClock{start.Add(time.Second), boot + time.Second},
+			time.Second, 0,
+		},
+		{
+			"suspended for an hour",
+			tickClock{start, boot}, tickClock{start.Add(time.Hour + time.Second), boot + time.Hour + time.Second},
+			time.Hour + time.Second, 0,
+		},
+		{
+			"clock set forward",
+			tickClock{start, boot}, tickClock{start.Add(time.Hour + time.Second), boot + time.Second},
+			time.Second, time.Hour,
+		},
+		{
+			"clock set back",
+			tickClock{start, boot}, tickClock{start.Add(-time.Hour + time.Second), boot + time.Second},
+			time.Second, -time.Hour,
+		},
+		{
+			// Without a boot clock setting the clock forward can't be told
+			// from a suspend and uses up the hour
+			"clock set forward, no boot clock",
+			tickClock{start, 0}, tickClock{start.Add(time.Hour + time.Second), 0},
+			time.Hour + time.Second, 0,
+		},
+		{
+			"clock set back, no boot clock",
+			tickClock{start, 0}, tickClock{start.Add(-time.Hour), 0},
+			0, -time.Hour,
+		},
+	} {
+		running, _, changed := runningTime(tc.last, tc.now)
+		if running != tc.running || changed != tc.changed {
+			t.Errorf("%s: running %s, changed %s, want %s, %s", tc.name, running, changed, tc.running, tc.changed)
+		}
+	}
+}
+
+func TestAdvanceCountdownsIgnoresClockChange(t *testing.T) {
+	start := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	m := model{}
+	m.data.Reminders = []Reminder{{Status: "active", IsCountdown: true}}
+	m.data.Reminders[0].startCountdown(30*time.Minute, start)
+
+	// The clock is set an hour ahead between two ticks
+	m.advanceCountdowns(tickClock{start, time.Minute}, tickClock{start.Add(time.Hour + time.Second), time.Minute + time.Second})
+	reminder := m.data.Reminders[0]
+	if got := reminder.countdownRemaining(); got != 30*time.Minute-time.Second {
+		t.Errorf("%s left after the clock moved ahead, want 29m59s", got)
+	}
+	if want := start.Add(time.Hour + 30*time.Minute); !reminder.TargetTime.Equal(want) {
+		t.Errorf("target %s, want it moved with the clock to %s", reminder.TargetTime, want)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Dimmed style for rolling todos that are blocked by unfinished todos
+var blockedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+// parseDependencies reads "3, 5" or "#3 #5" into todo IDs
+func parseDependencies(input string) ([]int, error) {
+	ids := []int{}
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		id, err := strconv.Atoi(strings.TrimLeft(field, "#"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid todo ID %q", field)
+		}
+		if !containsID(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return ids, nil
+}
+
+func formatDependencies(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("#%d", id)
+	}
+	return strings.Join(parts, " ")
+}
+
+func containsID(ids []int, id int) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
+
+func todoByID(todos []RollingTodo, id int) *RollingTodo {
+	for i := range todos {
+		if todos[i].ID == id {
+			return &todos[i]
+		}
+	}
+	return nil
+}
+
+// isBlocked reports whether any todo this one depends on is still open
+func isBlocked(todo RollingTodo, todos []RollingTodo) bool {
+	for _, id := range todo.BlockedBy {
+		if dep := todoByID(todos, id); dep != nil && !dep.Done {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDependencies checks that todo id may be blocked by deps: every
+// dependency must exist, and following the existing BlockedBy edges from
+// them must never lead back to id.
+func validateDependencies(todos []RollingTodo, id int, deps []int) error {
+	for _, dep := range deps {
+		if dep == id {
+			return fmt.Errorf("a todo can't be blocked by itself")
+		}
+		if todoByID(todos, dep) == nil {
+			return fmt.Errorf("no todo with ID #%d", dep)
+		}
+	}
+
+	visited := map[int]bool{}
+	var reaches func(from int, path []int) []int
+	reaches = func(from int, path []int) []int {
+		if from == id {
+			return path
+		}
+		if visited[from] {
+			return nil
+		}
+		visited[from] = true
+		if todo := todoByID(todos, from); todo != nil {
+			for _, next := range todo.BlockedBy {
+				if cycle := reaches(next, append(path, next)); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, dep := range deps {
+		if cycle := reaches(dep, []int{id, dep}); cycle != nil {
+			return fmt.Errorf("dependency cycle: %s", strings.ReplaceAll(formatDependencies(cycle), " ", " → "))
+		}
+	}
+	return nil
+}
+
+// removeDependency drops a deleted todo from every BlockedBy list
+func removeDependency(todos []RollingTodo, id int) {
+	for i := range todos {
+		deps := todos[i].BlockedBy[:0]
+		for _, dep := range todos[i].BlockedBy {
+			if dep != id {
+				deps = append(deps, dep)
+			}
+		}
+		if len(deps) == 0 {
+			deps = nil
+		}
+		todos[i].BlockedBy = deps
+	}
+}
+
+// fitStyled truncates text so that it still fits a table cell once styled;
+// the table truncates cells by raw width, escape codes included.
+func fitStyled(style lipgloss.Style, text string, width int) string {
+	overhead := runewidth.StringWidth(style.Render("x")) - 1
+	if room := width - overhead; runewidth.StringWidth(text) > room {
+		if room < 1 {
+			return runewidth.Truncate(text, width, "…")
+		}
+		text = runewidth.Truncate(text, room, "…")
+	}
+	return style.Render(text)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Do not disturb. While DND is switched on, or during the scheduled quiet
+// hours, notifications are held back: fired reminders are queued and summed
+// up in one notification once DND ends, while lead alerts and pomodoro phases
+// are dropped since they would be stale by then. Reminders marked to break
+// through DND always notify.
+
+// HeldReminder is a reminder that fired during DND
+type HeldReminder struct {
+	ID       int       `json:"id"`
+	Reminder string    `json:"reminder"`
+	FiredAt  time.Time `json:"fired_at"`
+	// Repeats that were held back as well
+	Repeats int `json:"repeats,omitempty"`
+}
+
+var dndStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Bold(true)
+
+// dndActive reports whether notifications are held back at now
+func (m *model) dndActive(now time.Time) bool {
+	return m.data.Notify.DoNotDisturb || inClockRange(m.data.Notify.QuietHours, now)
+}
+
+// hold queues a notification for the summary sent when DND ends. Only
+// notifications about a reminder are kept.
+func (m *model) hold(n Notification, now time.Time) {
+	if n.ReminderID == 0 {
+		return
+	}
+	for i := range m.data.Held {
+		if m.data.Held[i].ID == n.ReminderID {
+			m.data.Held[i].Repeats++
+			return
+		}
+	}
+	m.data.Held = append(m.data.Held, HeldReminder{ID: n.ReminderID, Reminder: n.Message, FiredAt: now})
+}
+
+// checkDND sends the summary of held reminders once DND is over
+func (m *model) checkDND(now time.Time) {
+	if len(m.data.Held) == 0 || m.dndActive(now) {
+		return
+	}
+	lines := []string{}
+	for _, held := range m.data.Held {
+		line := fmt.Sprintf("%s %s", held.FiredAt.Format("15:04"), held.Reminder)
+		if held.Repeats > 0 {
+			line += fmt.Sprintf(" (repeated %d×)", held.Repeats)
+		}
+		lines = append(lines, line)
+	}
+	title := "1 reminder during Do Not Disturb"
+	if len(m.data.Held) > 1 {
+		title = fmt.Sprintf("%d reminders during Do Not Disturb", len(m.data.Held))
+	}
+	m.logActivity("delivered", "reminder", 0, title)
+	m.data.Held = nil
+	m.notify(nil, "", Notification{Title: title, Message: strings.Join(lines, "\n")})
+	m.statusMsg = "🌙 " + title + ": " + strings.Join(lines, ", ")
+	m.statusColor = "141"
+	m.statusExpiry = time.Now().Add(10 * time.Second)
+	m.saveQuiet()
+}
+
+func (m *model) toggleDND() {
+	m.data.Notify.DoNotDisturb = !m.data.Notify.DoNotDisturb
+	m.saveQuiet()
+	if m.data.Notify.DoNotDisturb {
+		m.statusMsg = "🌙 Do Not Disturb on"
+	} else {
+		m.statusMsg = "🔔 Do Not Disturb off"
+		if inClockRange(m.data.Notify.QuietHours, time.Now()) {
+			m.statusMsg += ", quiet hours until " + quietHoursEnd(m.data.Notify.QuietHours)
+		}
+	}
+	m.statusColor = "141"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
+
+// quietHoursEnd returns the end of a range like "22:00-07:00"
+func quietHoursEnd(spec string) string {
+	_, end, err := parseClockRange(spec)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", end/60, end%60)
+}
+
+// dndHeader shows next to the app title that notifications are held back
+func (m *model) dndHeader() string {
+	now := time.Now()
+	if !m.dndActive(now) {
+		return ""
+	}
+	label := "🌙 DND"
+	if !m.data.Notify.DoNotDisturb {
+		label = "🌙 Quiet until " + quietHoursEnd(m.data.Notify.QuietHours)
+	}
+	if len(m.data.Held) > 0 {
+		label += fmt.Sprintf(" (%d held)", len(m.data.Held))
+	}
+	return dndStyle.Render(label)
+}
+
+// parseClockRange reads "22:00-07:00" into minutes after midnight
+func parseClockRange(spec string) (start, end int, err error) {
+	from, to, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid time range %q, use HH:MM-HH:MM", spec)
+	}
+	startTime, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time range %q, use HH:MM-HH:MM", spec)
+	}
+	endTime, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time range %q, use HH:MM-HH:MM", spec)
+	}
+	return startTime.Hour()*60 + startTime.Minute(), endTime.Hour()*60 + endTime.Minute(), nil
+}
+
+// inClockRange reports whether now falls in a range like "22:00-07:00",
+// which may wrap past midnight. An empty or invalid range never matches.
+func inClockRange(spec string, now time.Time) bool {
+	if spec == "" {
+		return false
+	}
+	start, end, err := parseClockRange(spec)
+	if err != nil || start == end {
+		return false
+	}
+	minute := now.Hour()*60 + now.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDNDActiveDuringQuietHours(t *testing.T) {
+	m := model{}
+	m.data.Notify.QuietHours = "22:00-07:00"
+	for _, tc := range []struct {
+		clock string
+		want  bool
+	}{{"21:59", false}, {"22:00", true}, {"03:00", true}, {"07:00", false}} {
+		now, _ := time.Parse("15:04", tc.clock)
+		if got := m.dndActive(now); got != tc.want {
+			t.Errorf("dndActive at %s = %v, want %v", tc.clock, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func editTestModel() model {
+	m := model{expanded: map[int]bool{}, marked: map[int]bool{}}
+	m.data.Dailies = []Daily{{ID: 1, Task: "Stretch", Priority: "HIGH", Category: "health", Tags: []string{"am"}}}
+	m.data.RollingTodos = []RollingTodo{{ID: 1, Task: "Write report", Priority: "MEDIUM", Tags: []string{"work"}, Subtasks: []Subtask{{ID: 1, Task: "Outline"}}}}
+	m.data.Reminders = []Reminder{{ID: 1, Reminder: "Tea", AlarmOrCountdown: "30m", Status: "active", NagMinutes: 5, LeadMinutes: []int{10}}}
+	m.data.Reminders[0].startCountdown(30*time.Minute, time.Now().Add(-10*time.Minute))
+	m.data.Glossary = []GlossaryItem{{ID: 1, Lang: "git", Command: "git status", Meaning: "show changes"}}
+	m.data.Projects = []Project{{ID: 1, Name: "Launch", Status: "active"}}
+	m.setupTables()
+	m.saved = snapshot(m.data)
+	return m
+}
+
+// selectFirst moves the cursor past category headers to the first item
+func selectFirst(m *model, tab int) {
+	for cursor, idx := range m.rowIndex[tab-2] {
+		if idx == 0 {
+			m.tables[tab-2].SetCursor(cursor)
+			return
+		}
+	}
+}
+
+func TestSaveEditSkipsUnchanged(t *testing.T) {
+	tempConfigDir(t)
+
+	for tab := 2; tab <= 6; tab++ {
+		m := editTestModel()
+		m.activeTab = tab
+		selectFirst(&m, tab)
+		m.startEditing()
+		if !m.editing {
+			t.Fatalf("tab %d: no edit form", tab)
+		}
+		before := m.data.Reminders[0].CountedAt
+		if err := m.saveEdit(); err != nil {
+			t.Fatalf("tab %d: %v", tab, err)
+		}
+		if len(m.undoStack) != 0 {
+			t.Errorf("tab %d: unchanged edit pushed %d undo steps", tab, len(m.undoStack))
+		}
+		if !m.data.Reminders[0].CountedAt.Equal(before) {
+			t.Errorf("tab %d: unchanged edit restarted the countdown", tab)
+		}
+
+		m = editTestModel()
+		m.activeTab = tab
+		selectFirst(&m, tab)
+		m.startEditing()
+		m.inputs[0].SetValue(m.inputs[0].Value() + " (edited)")
+		if err := m.saveEdit(); err != nil {
+			t.Fatalf("tab %d: %v", tab, err)
+		}
+		if len(m.undoStack) != 1 {
+			t.Errorf("tab %d: edit pushed %d undo steps, want 1", tab, len(m.undoStack))
+		}
+	}
+}
+
+func TestSaveBulkEditSkipsUnchanged(t *testing.T) {
+	tempConfigDir(t)
+
+	m := editTestModel()
+	m.activeTab = 2
+	m.markedTab = 2
+	m.marked = map[int]bool{0: true}
+	m.startBulkEdit()
+	m.inputs[0].SetValue("high")
+	if err := m.saveEdit(); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.undoStack) != 0 {
+		t.Errorf("bulk edit to the same priority pushed %d undo steps", len(m.undoStack))
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Collapsible category groups on the Dailies and Rolling tables
+
+var groupHeaderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+
+// Columns of the Dailies table; fitStyled needs the widths for group headers
+var dailyColumns = []table.Column{
+	{Title: "Task", Width: 30},
+	{Title: "Priority", Width: 10},
+	{Title: "Category", Width: 15},
+	{Title: "Deadline", Width: 12},
+	{Title: "Status", Width: 25},
+	{Title: "Tags", Width: 20},
+}
+
+// groupedByCategory reports whether a tab is shown with category headers.
+// That is only the case while it is ordered by category; the board needs
+// every todo as a plain row.
+func (m *model) groupedByCategory(tab int) bool {
+	if tab == 3 && m.boardMode {
+		return false
+	}
+	key := m.sortPref(tab).Key
+	return key == "" || key == "category"
+}
+
+// startsGroup reports whether the item at position n of indices, which are
+// sorted by category, is the first of its category
+func startsGroup(indices []int, n int, category func(i int) string) bool {
+	return n == 0 || !strings.EqualFold(category(indices[n]), category(indices[n-1]))
+}
+
+// groupStats counts the items and the completed items of each category
+func groupStats(indices []int, category func(i int) string, done func(i int) bool) (map[string]int, map[string]int) {
+	counts, completed := map[string]int{}, map[string]int{}
+	for _, i := range indices {
+		key := strings.ToLower(category(i))
+		counts[key]++
+		if done(i) {
+			completed[key]++
+		}
+	}
+	return counts, completed
+}
+
+func groupKey(tab int, category string) string {
+	return fmt.Sprintf("%s:%s", sortTabNames[tab], strings.ToLower(category))
+}
+
+// groupTitle is the header label, e.g. "▾ Work (3)"
+func (m *model) groupTitle(tab int, category string, count int) string {
+	arrow := "▾"
+	if m.collapsed[groupKey(tab, category)] {
+		arrow = "▸"
+	}
+	if category == "" {
+		category = "Uncategorized"
+	}
+	return fmt.Sprintf("%s %s (%d)", arrow, category, count)
+}
+
+func (m *model) dailyGroupRow(category string, count, done int) table.Row {
+	row := make(table.Row, len(dailyColumns))
+	row[0] = m.groupTitle(2, category, count)
+	row[4] = fmt.Sprintf("%d/%d done", done, count)
+	for c := range row {
+		row[c] = fitStyled(groupHeaderStyle, row[c], dailyColumns[c].Width)
+	}
+	return row
+}
+
+func (m *model) rollingGroupRow(category string, count, done int) table.Row {
+	row := make(table.Row, len(rollingColumns))
+	row[1] = m.groupTitle(3, category, count)
+	row[6] = fmt.Sprintf("%d/%d", done, count)
+	for c := range row {
+		row[c] = fitStyled(groupHeaderStyle, row[c], rollingColumns[c].Width)
+	}
+	return row
+}
+
+// toggleGroup collapses or expands the category group under the cursor
+func (m *model) toggleGroup() {
+	if !m.groupedByCategory(m.activeTab) {
+		m.statusMsg = "Groups are only shown when sorted by category"
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+		return
+	}
+	tableIdx := m.activeTab - 2
+	cursor := m.tables[tableIdx].Cursor()
+	if cursor < 0 || cursor >= len(m.rowGroup[tableIdx]) {
+		return
+	}
+	category := m.rowGroup[tableIdx][cursor]
+	key := groupKey(m.activeTab, category)
+	m.collapsed[key] = !m.collapsed[key]
+
+	if tableIdx == 0 {
+		m.tables[0].SetRows(m.dailyRows())
+	} else {
+		m.tables[1].SetRows(m.rollingRows())
+	}
+	// Keep the cursor on the header of the group that was toggled
+	for row, idx := range m.rowIndex[tableIdx] {
+		if idx == -1 && strings.EqualFold(m.rowGroup[tableIdx][row], category) {
+			m.tables[tableIdx].SetCursor(row)
+			break
+		}
+	}
+}
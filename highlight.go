@@ -0,0 +1,210 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Minimal syntax highlighting for the glossary detail pane
+var (
+	hlCommandStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+	hlKeywordStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+	hlFlagStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	hlStringStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	hlVariableStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("176"))
+	hlNumberStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("141"))
+	hlCommentStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
+	hlPlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("180")).Italic(true)
+	hlOperatorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+)
+
+type syntax struct {
+	shell         bool
+	lineComment   string
+	hashComment   bool
+	keywords      map[string]bool
+	caseSensitive bool
+}
+
+func keywordSet(words string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+var syntaxes = map[string]syntax{
+	"go": {lineComment: "//", caseSensitive: true, keywords: keywordSet(
+		"break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var nil true false")},
+	"python": {hashComment: true, caseSensitive: true, keywords: keywordSet(
+		"and as assert async await break class continue def del elif else except finally for from global if import in is lambda nonlocal not or pass raise return try while with yield None True False")},
+	"javascript": {lineComment: "//", caseSensitive: true, keywords: keywordSet(
+		"async await break case catch class const continue default delete do else export extends finally for from function if import in instanceof let new of return switch this throw try typeof var void while yield null undefined true false")},
+	"rust": {lineComment: "//", caseSensitive: true, keywords: keywordSet(
+		"as async await break const continue crate else enum extern false fn for if impl in let loop match mod move mut pub ref return self Self static struct super trait true type unsafe use where while")},
+	"sql": {lineComment: "--", keywords: keywordSet(
+		"select from where insert into values update set delete create table drop alter add index join left right inner outer on group by order having limit offset as and or not null is in like distinct union all primary key foreign references")},
+	"shell": {shell: true, hashComment: true, caseSensitive: true, keywords: keywordSet(
+		"if then else elif fi for while until do done case esac in function return export local sudo")},
+}
+
+var langAliases = map[string]string{
+	"go": "go", "golang": "go",
+	"py": "python", "python": "python", "python3": "python",
+	"js": "javascript", "javascript": "javascript", "ts": "javascript", "typescript": "javascript", "node": "javascript",
+	"rs": "rust", "rust": "rust", "cargo": "shell",
+	"sql": "sql", "psql": "sql", "mysql": "sql", "sqlite": "sql", "postgres": "sql",
+}
+
+// syntaxFor picks a highlighter from the glossary Lang. Anything that is not a
+// known programming language is treated as a shell command (git, docker, ...).
+func syntaxFor(lang string) syntax {
+	if name, ok := langAliases[strings.ToLower(strings.TrimSpace(lang))]; ok {
+		return syntaxes[name]
+	}
+	return syntaxes["shell"]
+}
+
+func highlightCode(lang, code string) string {
+	syn := syntaxFor(lang)
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		lines[i] = highlightLine(syn, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func highlightLine(syn syntax, line string) string {
+	var out strings.Builder
+	runes := []rune(line)
+	expectCommand := syn.shell
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		rest := string(runes[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			out.WriteRune(r)
+			i++
+
+		case (syn.hashComment && r == '#' && (i == 0 || unicode.IsSpace(runes[i-1]))) ||
+			(syn.lineComment != "" && strings.HasPrefix(rest, syn.lineComment)):
+			out.WriteString(hlCommentStyle.Render(rest))
+			i = len(runes)
+
+		case r == '"' || r == '\'' || r == '`':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' && r != '\'' {
+					end++
+				}
+				end++
+			}
+			if end < len(runes) {
+				end++
+			} else {
+				end = len(runes)
+			}
+			out.WriteString(hlStringStyle.Render(string(runes[i:end])))
+			i = end
+			expectCommand = false
+
+		case syn.shell && r == '$':
+			end := i + 1
+			if end < len(runes) && (runes[end] == '{' || runes[end] == '(') {
+				closer := '}'
+				if runes[end] == '(' {
+					closer = ')'
+				}
+				for end < len(runes) && runes[end] != closer {
+					end++
+				}
+				if end < len(runes) {
+					end++
+				}
+			} else {
+				for end < len(runes) && (isWordRune(runes[end]) || runes[end] == '?' || runes[end] == '@') {
+					end++
+				}
+			}
+			out.WriteString(hlVariableStyle.Render(string(runes[i:end])))
+			i = end
+			expectCommand = false
+
+		case syn.shell && r == '<' && slices.Contains(runes[i:], '>') && i+1 < len(runes) && isWordRune(runes[i+1]):
+			// tldr/navi style placeholders such as <branch>
+			end := i + slices.Index(runes[i:], '>') + 1
+			out.WriteString(hlPlaceholderStyle.Render(string(runes[i:end])))
+			i = end
+			expectCommand = false
+
+		case strings.ContainsRune("|&;<>(){}[]=!", r):
+			end := i + 1
+			for end < len(runes) && strings.ContainsRune("|&;<>=", runes[end]) {
+				end++
+			}
+			op := string(runes[i:end])
+			out.WriteString(hlOperatorStyle.Render(op))
+			i = end
+			if syn.shell && (op == "|" || op == "||" || op == "&&" || op == ";" || op == "(" || op == "$(") {
+				expectCommand = true
+			}
+
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("|&;<>(){}[]\"'`$", runes[end]) {
+				if !syn.shell && !isWordRune(runes[end]) && runes[end] != '.' {
+					break
+				}
+				end++
+			}
+			if end == i {
+				end = i + 1
+			}
+			word := string(runes[i:end])
+			key := word
+			if !syn.caseSensitive {
+				key = strings.ToLower(word)
+			}
+
+			switch {
+			case syn.keywords[key]:
+				out.WriteString(hlKeywordStyle.Render(word))
+				// "sudo git ..." should still highlight git as the command
+				expectCommand = syn.shell && (key == "sudo" || key == "do" || key == "then" || key == "else")
+			case syn.shell && expectCommand && !strings.Contains(word, "="):
+				out.WriteString(hlCommandStyle.Render(word))
+				expectCommand = false
+			case syn.shell && strings.HasPrefix(word, "-"):
+				out.WriteString(hlFlagStyle.Render(word))
+			case isNumber(word):
+				out.WriteString(hlNumberStyle.Render(word))
+			default:
+				out.WriteString(word)
+			}
+			i = end
+		}
+	}
+	return out.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func isNumber(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsDigit(r) && r != '.' {
+			return false
+		}
+	}
+	return unicode.IsDigit(rune(word[0]))
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestHighlightCodeKeepsText(t *testing.T) {
+	for _, code := range []string{
+		"tar -xf <archive>",
+		"ls <ääää> --color",
+		"cp <ファイル> <宛先ディレクトリ>",
+		"tar czf <архив.tar.gz> <путь/к/каталогу>",
+		"echo <ünclosed placeholder",
+		"git log <ñ",
+		"grep -r <é> | less",
+		"ls <" + strings.Repeat("ä", 30) + ">",
+	} {
+		out := highlightCode("tar", code)
+		if strings.ContainsRune(out, 0) {
+			t.Errorf("highlightCode(%q) contains NUL: %q", code, out)
+		}
+		if got := ansi.Strip(out); got != code {
+			t.Errorf("highlightCode(%q) shows %q", code, got)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Shell history import for the glossary
+type historyCandidate struct {
+	Command string
+	Count   int
+	Score   int
+}
+
+// Commands that are too common to be worth a glossary entry on their own
+var trivialCommands = map[string]bool{
+	"ls": true, "ll": true, "la": true, "cd": true, "pwd": true, "clear": true,
+	"exit": true, "history": true, "cls": true, "fg": true, "bg": true, "jobs": true,
+}
+
+func defaultHistoryFiles() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	fishDir := os.Getenv("XDG_DATA_HOME")
+	if fishDir == "" {
+		fishDir = filepath.Join(home, ".local", "share")
+	}
+
+	candidates := []string{
+		os.Getenv("HISTFILE"),
+		filepath.Join(home, ".bash_history"),
+		filepath.Join(home, ".zsh_history"),
+		filepath.Join(home, ".zhistory"),
+		filepath.Join(fishDir, "fish", "fish_history"),
+	}
+
+	files := []string{}
+	seen := map[string]bool{}
+	for _, file := range candidates {
+		if file == "" || seen[file] {
+			continue
+		}
+		seen[file] = true
+		if _, err := os.Stat(file); err == nil {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func parseHistoryFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(filepath.Base(path), "fish") {
+		return parseFishHistory(data), nil
+	}
+	if bytes.HasPrefix(data, []byte(": ")) || strings.Contains(filepath.Base(path), "zsh") {
+		return parseZshHistory(data), nil
+	}
+	return parseBashHistory(data), nil
+}
+
+func parseBashHistory(data []byte) []string {
+	commands := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// HISTTIMEFORMAT writes "#1700000000" lines before each command
+		if line == "" || isHistoryTimestamp(line) {
+			continue
+		}
+		commands = append(commands, line)
+	}
+	return commands
+}
+
+func isHistoryTimestamp(line string) bool {
+	if len(line) < 2 || line[0] != '#' {
+		return false
+	}
+	for _, r := range line[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func parseZshHistory(data []byte) []string {
+	data = unmetafyZsh(data)
+	commands := []string{}
+	var current strings.Builder
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Extended history format: ": 1700000000:0;command"
+		if current.Len() == 0 && strings.HasPrefix(line, ": ") {
+			if idx := strings.Index(line, ";"); idx != -1 {
+				line = line[idx+1:]
+			}
+		}
+
+		// Multi-line commands are stored with a trailing backslash
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSuffix(line, "\\"))
+			current.WriteString("\n")
+			continue
+		}
+
+		current.WriteString(line)
+		command := strings.TrimSpace(current.String())
+		current.Reset()
+		if command != "" {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// zsh stores some bytes "metafied": 0x83 followed by the byte xor 32
+func unmetafyZsh(data []byte) []byte {
+	if bytes.IndexByte(data, 0x83) == -1 {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] == 0x83 && i+1 < len(data) {
+			i++
+			out = append(out, data[i]^32)
+			continue
+		}
+		out = append(out, data[i])
+	}
+	return out
+}
+
+func parseFishHistory(data []byte) []string {
+	commands := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "- cmd: ") {
+			continue
+		}
+		command := strings.TrimPrefix(line, "- cmd: ")
+		// fish escapes newlines and backslashes inside the cmd value
+		command = strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(command)
+		command = strings.TrimSpace(command)
+		if command != "" {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// rankHistory groups identical commands and ranks them so that long or
+// frequently repeated commands come first. Commands already in the glossary
+// are skipped.
+func rankHistory(commands []string, existing []GlossaryItem) []historyCandidate {
+	known := map[string]bool{}
+	for _, item := range existing {
+		known[strings.ToLower(strings.TrimSpace(item.Command))] = true
+	}
+
+	counts := map[string]int{}
+	order := []string{}
+	for _, command := range commands {
+		command = strings.TrimSpace(command)
+		if command == "" || known[strings.ToLower(command)] {
+			continue
+		}
+		if counts[command] == 0 {
+			order = append(order, command)
+		}
+		counts[command]++
+	}
+
+	candidates := []historyCandidate{}
+	for _, command := range order {
+		count := counts[command]
+		fields := strings.Fields(command)
+		if len(fields) == 1 && trivialCommands[fields[0]] {
+			continue
+		}
+		// Short one-off commands are rarely worth remembering
+		if count < 2 && len(fields) < 3 && len(command) < 20 {
+			continue
+		}
+
+		length := len(command)
+		if length > 120 {
+			length = 120
+		}
+		candidates = append(candidates, historyCandidate{
+			Command: command,
+			Count:   count,
+			Score:   count*20 + length,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
+
+// loadHistoryCandidates reads the given history files (or the default ones)
+// and returns the ranked candidates, capped at limit entries.
+func loadHistoryCandidates(files []string, existing []GlossaryItem, limit int) ([]historyCandidate, error) {
+	if len(files) == 0 {
+		files = defaultHistoryFiles()
+	}
+
+	commands := []string{}
+	for _, file := range files {
+		parsed, err := parseHistoryFile(file)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, parsed...)
+	}
+
+	candidates := rankHistory(commands, existing)
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates, nil
+}
+
+// inferLang guesses the glossary Lang from the first word of a command,
+// skipping sudo, env and leading VAR=value assignments.
+func inferLang(command string) string {
+	for _, field := range strings.Fields(command) {
+		if field == "sudo" || field == "env" || field == "time" || field == "nohup" {
+			continue
+		}
+		if strings.Contains(field, "=") && !strings.HasPrefix(field, "-") {
+			continue
+		}
+		return filepath.Base(field)
+	}
+	return ""
+}
+
+// Line breaks as written by shells and editors on any platform
+var lineBreaks = strings.NewReplacer("\r\n", " ↵ ", "\n", " ↵ ", "\r", " ↵ ")
+
+// oneLine flattens multi-line commands for list display
+func oneLine(command string) string {
+	return lineBreaks.Replace(command)
+}
+
+func historyGlossaryItem(candidate historyCandidate, id int) GlossaryItem {
+	return GlossaryItem{
+		ID:      id,
+		Lang:    normalizeText(inferLang(candidate.Command)),
+		Command: normalizeText(candidate.Command),
+		Example: normalizeText(candidate.Command),
+	}
+}
+
+func (m *model) startHistoryImport() {
+	candidates, err := loadHistoryCandidates(nil, m.data.Glossary, 50)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Could not read shell history: %v", err)
+		m.statusColor = "196"
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+		return
+	}
+	if len(candidates) == 0 {
+		m.statusMsg = "No new commands found in shell history"
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+		return
+	}
+
+	m.importing = true
+	m.importItems = candidates
+	m.importMarked = map[int]bool{}
+	m.importCursor = 0
+}
+
+func (m model) handleImportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.importing = false
+		m.importItems = nil
+		return m, showStatus("❌ Import cancelled", "196")
+	case "up", "k":
+		if m.importCursor > 0 {
+			m.importCursor--
+		}
+	case "down", "j":
+		if m.importCursor < len(m.importItems)-1 {
+			m.importCursor++
+		}
+	case " ", "x":
+		// Unmarked entries are removed so the map only holds marked ones,
+		// which select all counts on
+		if m.importMarked[m.importCursor] {
+			delete(m.importMarked, m.importCursor)
+		} else {
+			m.importMarked[m.importCursor] = true
+		}
+	case "a":
+		allMarked := len(m.importMarked) == len(m.importItems)
+		m.importMarked = map[int]bool{}
+		if !allMarked {
+			for i := range m.importItems {
+				m.importMarked[i] = true
+			}
+		}
+	case "enter":
+		imported := 0
+		for i, candidate := range m.importItems {
+			if m.importMarked[i] {
+				m.data.Glossary = append(m.data.Glossary, historyGlossaryItem(candidate, nextGlossaryID(m.data.Glossary)))
+				imported++
+			}
+		}
+		m.importing = false
+		m.importItems = nil
+		if imported == 0 {
+			return m, showStatus("Nothing selected to import", "226")
+		}
+		m.tables[3].SetRows(m.glossaryRows())
+		m.logActivity("imported", "glossary", 0, fmt.Sprintf("%d entries from shell history", imported))
+		m.save("history import")
+		return m, showStatus(fmt.Sprintf("✅ Imported %d glossary entries", imported), "82")
+	}
+	return m, nil
+}
+
+func (m model) importView() string {
+	header := headerStyle.Render("📥 Import from shell history")
+
+	// Keep the cursor visible in small terminals
+	visible := len(m.importItems)
+	if m.height > 0 && visible > m.height-6 {
+		visible = m.height - 6
+		if visible < 5 {
+			visible = 5
+		}
+	}
+	start := 0
+	if m.importCursor >= visible {
+		start = m.importCursor - visible + 1
+	}
+	end := start + visible
+	if end > len(m.importItems) {
+		end = len(m.importItems)
+	}
+
+	lines := []string{}
+	for i := start; i < end; i++ {
+		candidate := m.importItems[i]
+		check := "[ ]"
+		if m.importMarked[i] {
+			check = statusDoneStyle.Render("[x]")
+		}
+		line := fmt.Sprintf("%s %-10s %s %s", check, inferLang(candidate.Command), oneLine(candidate.Command),
+			bulletStyle.Render(fmt.Sprintf("x%d", candidate.Count)))
+		if i == m.importCursor {
+			line = activeTabStyle.Render(">") + " " + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	footer := keyStyle.Render("↑↓") + ": " + actionStyle.Render("navigate") + bulletStyle.Render(" • ") +
+		keyStyle.Render("space") + ": " + actionStyle.Render("select") + bulletStyle.Render(" • ") +
+		keyStyle.Render("a") + ": " + actionStyle.Render("select all") + bulletStyle.Render(" • ") +
+		keyStyle.Render("enter") + ": " + actionStyle.Render("import") + bulletStyle.Render(" • ") +
+		keyStyle.Render("esc") + ": " + actionStyle.Render("cancel")
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		header,
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		footer,
+	)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func pressImportKey(t *testing.T, m model, key string) model {
+	t.Helper()
+	next, _ := m.handleImportKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	return next.(model)
+}
+
+func TestImportSelectAllAfterUnmarking(t *testing.T) {
+	m := model{importing: true, importMarked: map[int]bool{}}
+	m.importItems = []historyCandidate{{Command: "ls"}, {Command: "pwd"}, {Command: "id"}}
+
+	m = pressImportKey(t, m, "a")
+	m = pressImportKey(t, m, "x") // unmark the first
+	m = pressImportKey(t, m, "a")
+	for i := range m.importItems {
+		if !m.importMarked[i] {
+			t.Fatalf("select all left %d unmarked: %v", i, m.importMarked)
+		}
+	}
+
+	m = pressImportKey(t, m, "a")
+	if len(m.importMarked) != 0 {
+		t.Errorf("select all with everything marked left %v", m.importMarked)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Glossary imports from the command line. `lif glossary import-*` appends the
+// new entries to imports.jsonl instead of saving config.json, which a running
+// TUI would overwrite on its next save. The TUI adds them to the glossary on
+// the next tick, or on startup when it wasn't running.
+
+func importsPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "lif", "imports.jsonl"), nil
+}
+
+func appendImports(items ...GlossaryItem) error {
+	path, err := importsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	for _, item := range items {
+		line, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// takeImports reads and removes the waiting imports. The file is renamed
+// first, so entries appended meanwhile go to a new file instead of being
+// lost; one left over from a crash is read again.
+func takeImports() []GlossaryItem {
+	path, err := importsPath()
+	if err != nil {
+		return nil
+	}
+	taken := path + ".taken"
+	if err := os.Rename(path, taken); err != nil && !os.IsNotExist(err) {
+		return nil
+	}
+	file, err := os.Open(taken)
+	if err != nil {
+		return nil
+	}
+	defer os.Remove(taken)
+	defer file.Close()
+
+	items := []GlossaryItem{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var item GlossaryItem
+		if json.Unmarshal(scanner.Bytes(), &item) == nil {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// mergeImports adds the entries imported from the command line to the
+// glossary
+func (m *model) mergeImports() {
+	items := takeImports()
+	if len(items) == 0 {
+		return
+	}
+	added := mergeGlossary(&m.data, items)
+	if added == 0 {
+		return
+	}
+	m.tables[3].SetRows(m.glossaryRows())
+	m.save("glossary import")
+	m.statusMsg = fmt.Sprintf("📥 Added %d imported glossary entries", added)
+	m.statusColor = "82"
+	m.statusExpiry = time.Now().Add(5 * time.Second)
+}
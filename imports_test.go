@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMergeImports(t *testing.T) {
+	tempConfigDir(t)
+
+	// Queued by the command line while the TUI was running
+	if err := appendImports(GlossaryItem{Lang: "git", Command: "git status"}, GlossaryItem{Lang: "ls", Command: "ls -la"}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := model{}
+	m.data.Glossary = []GlossaryItem{{ID: 4, Lang: "git", Command: "git status"}}
+	m.mergeImports()
+
+	if len(m.data.Glossary) != 2 || m.data.Glossary[1].Command != "ls -la" || m.data.Glossary[1].ID != 5 {
+		t.Errorf("glossary = %+v, want ls -la added as #5 and the duplicate skipped", m.data.Glossary)
+	}
+	if saved := loadData().Glossary; len(saved) != 2 {
+		t.Errorf("config.json has %d glossary entries, want 2", len(saved))
+	}
+	path, _ := importsPath()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("imports.jsonl left behind: %v", err)
+	}
+
+	m.mergeImports()
+	if len(m.data.Glossary) != 2 {
+		t.Errorf("merged twice: %+v", m.data.Glossary)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lead alerts warn a set number of minutes before a reminder fires. Each
+// lead fires once per target time: LeadsSent lists the leads already sent for
+// LeadTarget, so editing or restarting a reminder arms them again.
+
+// parseLeads reads "10m, 1m" (or "1h", or bare minutes) into minutes,
+// largest first
+func parseLeads(input string) ([]int, error) {
+	leads := []int{}
+	for _, field := range strings.Split(input, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		minutes, err := strconv.Atoi(field)
+		if err != nil {
+			d, parseErr := time.ParseDuration(field)
+			if parseErr != nil || d < time.Minute || d%time.Minute != 0 {
+				return nil, fmt.Errorf("invalid alert time %q, use whole minutes like 10m or 1h", field)
+			}
+			minutes = int(d.Minutes())
+		}
+		if minutes <= 0 {
+			return nil, fmt.Errorf("invalid alert time %q, use whole minutes like 10m or 1h", field)
+		}
+		leads = append(leads, minutes)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(leads)))
+	return leads, nil
+}
+
+func formatLead(minutes int) string {
+	if minutes >= 60 && minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+func formatLeads(leads []int) string {
+	parts := []string{}
+	for _, minutes := range leads {
+		parts = append(parts, formatLead(minutes))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (r Reminder) leadSent(minutes int) bool {
+	if !r.LeadTarget.Equal(r.TargetTime) {
+		return false
+	}
+	for _, sent := range r.LeadsSent {
+		if sent == minutes {
+			return true
+		}
+	}
+	return false
+}
+
+// inLeadWindow reports whether an active reminder is within its largest
+// lead of firing
+func (r Reminder) inLeadWindow(now time.Time) bool {
+	if r.Status != "active" || len(r.LeadMinutes) == 0 || r.TargetTime.IsZero() {
+		return false
+	}
+	remaining := r.TargetTime.Sub(now)
+	largest := 0
+	for _, minutes := range r.LeadMinutes {
+		if minutes > largest {
+			largest = minutes
+		}
+	}
+	return remaining > 0 && remaining <= time.Duration(largest)*time.Minute
+}
+
+// checkLeads sends the lead alerts that have come due. When several are due
+// at once (lif was closed) only the closest one is sent.
+func (m *model) checkLeads(now time.Time) {
+	for i := range m.data.Reminders {
+		reminder := &m.data.Reminders[i]
+		if reminder.Status != "active" || reminder.TargetTime.IsZero() || !now.Before(reminder.TargetTime) {
+			continue
+		}
+		remaining := reminder.TargetTime.Sub(now)
+
+		closest := 0
+		for _, minutes := range reminder.LeadMinutes {
+			if remaining > time.Duration(minutes)*time.Minute || reminder.leadSent(minutes) {
+				continue
+			}
+			if !reminder.LeadTarget.Equal(reminder.TargetTime) {
+				reminder.LeadTarget = reminder.TargetTime
+				reminder.LeadsSent = nil
+			}
+			reminder.LeadsSent = append(reminder.LeadsSent, minutes)
+			if closest == 0 || minutes < closest {
+				closest = minutes
+			}
+		}
+		if closest == 0 {
+			continue
+		}
+
+		in := formatLead(int(remaining.Round(time.Minute).Minutes()))
+		if remaining < time.Minute {
+			in = "less than a minute"
+		}
+		m.logItem(fmt.Sprintf("alert %s before", formatLead(closest)), 4, i)
+		m.notify(reminder.Notify, reminder.Sound, Notification{Title: "Reminder in " + in, Message: reminder.Reminder, BreakThrough: reminder.BreakDND})
+		m.statusMsg = fmt.Sprintf("⏰ In %s: %s", in, reminder.Reminder)
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(5 * time.Second)
+		m.saveQuiet()
+	}
+}
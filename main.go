@@ -1,11 +1,12 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
 	"sort"
@@ -21,21 +22,41 @@ import (
 
 // Data structures
 type Daily struct {
-	ID            int       `json:"id"`
-	Task          string    `json:"task"`
-	Priority      string    `json:"priority"`
-	Category      string    `json:"category"`
-	Deadline      string    `json:"deadline"`
-	Status        string    `json:"status"`
-	LastCompleted time.Time `json:"last_completed"`
+	ID            int         `json:"id"`
+	Task          string      `json:"task"`
+	Priority      string      `json:"priority"`
+	Category      string      `json:"category"`
+	Deadline      string      `json:"deadline"`
+	Status        string      `json:"status"`
+	LastCompleted time.Time   `json:"last_completed"`
+	Tags          []string    `json:"tags,omitempty"`
+	Order         int         `json:"order,omitempty"`
+	TimeEntries   []TimeEntry `json:"time_entries,omitempty"`
 }
 
 type RollingTodo struct {
-	ID       int    `json:"id"`
-	Task     string `json:"task"`
-	Priority string `json:"priority"`
-	Category string `json:"category"`
-	Deadline string `json:"deadline"`
+	ID          int         `json:"id"`
+	Task        string      `json:"task"`
+	Priority    string      `json:"priority"`
+	Category    string      `json:"category"`
+	Deadline    string      `json:"deadline"`
+	Tags        []string    `json:"tags,omitempty"`
+	Done        bool        `json:"done,omitempty"`
+	CompletedAt time.Time   `json:"completed_at"`
+	Subtasks    []Subtask   `json:"subtasks,omitempty"`
+	BlockedBy   []int       `json:"blocked_by,omitempty"`
+	ProjectID   int         `json:"project_id,omitempty"`
+	Column      string      `json:"column,omitempty"`
+	Order       int         `json:"order,omitempty"`
+	TimeEntries []TimeEntry `json:"time_entries,omitempty"`
+	Pomodoros   int         `json:"pomodoros,omitempty"`
+}
+
+// Checklist item under a rolling todo
+type Subtask struct {
+	ID   int    `json:"id"`
+	Task string `json:"task"`
+	Done bool   `json:"done"`
 }
 
 type Reminder struct {
@@ -49,30 +70,95 @@ type Reminder struct {
 	IsCountdown      bool          `json:"is_countdown"`
 	Notified         bool          `json:"notified"`
 	PausedRemaining  time.Duration `json:"paused_remaining"`
+	Countdown
+	Tags  []string `json:"tags,omitempty"`
+	Order int      `json:"order,omitempty"`
+	// Notification backends, the configured defaults when empty
+	Notify []string `json:"notify,omitempty"`
+	// Sound name or path, "none" for silence; the default sound when empty
+	Sound string `json:"sound,omitempty"`
+	// Repeat the notification this often until acknowledged; 0 notifies once
+	NagMinutes     int       `json:"nag_minutes,omitempty"`
+	Nags           int       `json:"nags,omitempty"`
+	LastNotified   time.Time `json:"last_notified,omitempty"`
+	AcknowledgedAt time.Time `json:"acknowledged_at,omitempty"`
+	// Minutes before the target time to send an alert, largest first
+	LeadMinutes []int     `json:"lead_minutes,omitempty"`
+	LeadsSent   []int     `json:"leads_sent,omitempty"`
+	LeadTarget  time.Time `json:"lead_target,omitempty"`
+	// Notify even during Do Not Disturb
+	BreakDND bool `json:"break_dnd,omitempty"`
 }
 
 type GlossaryItem struct {
-	ID      int    `json:"id"`
-	Lang    string `json:"lang"`
-	Command string `json:"command"`
-	Usage   string `json:"usage"`
-	Example string `json:"example"`
-	Meaning string `json:"meaning"`
+	ID      int      `json:"id"`
+	Lang    string   `json:"lang"`
+	Command string   `json:"command"`
+	Usage   string   `json:"usage"`
+	Example string   `json:"example"`
+	Meaning string   `json:"meaning"`
+	Tags    []string `json:"tags,omitempty"`
+	Order   int      `json:"order,omitempty"`
+}
+
+type Project struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+	Status      string `json:"status"`
+	Due         string `json:"due"`
+	Archived    bool   `json:"archived,omitempty"`
+	Order       int    `json:"order,omitempty"`
 }
 
 type AppData struct {
+	Version      int            `json:"version"`
 	Dailies      []Daily        `json:"dailies"`
 	RollingTodos []RollingTodo  `json:"rolling_todos"`
 	Reminders    []Reminder     `json:"reminders"`
 	Glossary     []GlossaryItem `json:"glossary"`
+	Projects     []Project      `json:"projects"`
+	// Sort order chosen per tab, keyed by sortTabNames
+	SortPrefs map[string]SortPref `json:"sort_prefs,omitempty"`
+	// Deleted items that can still be restored
+	Trash []TrashItem `json:"trash,omitempty"`
+	// Pomodoro timer state, lengths and daily counts
+	Pomodoro Pomodoro `json:"pomodoro"`
+	// Stopwatch tab with its laps
+	Stopwatch Stopwatch `json:"stopwatch"`
+	// Notification backends and their settings
+	Notify NotifySettings `json:"notifications"`
+	// Reminders that fired during Do Not Disturb
+	Held []HeldReminder `json:"held,omitempty"`
 }
 
+// Current config.json layout version, see migrateData
+const dataVersion = 1
+
+// Columns of the Rolling table; fitStyled needs the widths when dimming
+// blocked todos
+var rollingColumns = []table.Column{
+	{Title: "ID", Width: 4},
+	{Title: "Task", Width: 30},
+	{Title: "Priority", Width: 10},
+	{Title: "Category", Width: 12},
+	{Title: "Project", Width: 12},
+	{Title: "Deadline", Width: 12},
+	{Title: "Progress", Width: 8},
+	{Title: "Blocked By", Width: 10},
+	{Title: "Tags", Width: 20},
+}
+
+// Rows reserved for the glossary detail pane when it is shown below the table
+const glossaryDetailHeight = 12
+
 type statusMsg struct {
 	message string
 	color   string
 }
 
-type tickMsg time.Time
+type tickMsg tickClock
 
 type notificationMsg struct {
 	reminder Reminder
@@ -81,7 +167,7 @@ type notificationMsg struct {
 // Model
 type model struct {
 	activeTab     int
-	tables        [4]table.Model
+	tables        [5]table.Model
 	data          AppData
 	editing       bool
 	editingTab    int
@@ -93,9 +179,89 @@ type model struct {
 	statusExpiry  time.Time
 	width         int
 	height        int
-	lastTick      time.Time
+	lastTick      tickClock
 	confirmDelete bool
 	deleteTarget  string
+
+	// Table row -> index into the matching m.data slice, rebuilt by the row
+	// functions since filtered tables no longer line up with the data
+	rowIndex [5][]int
+	// Subtask index for each Rolling row, -1 for the todo itself
+	rowSubtask []int
+	// Category of each Dailies/Rolling row; header rows have rowIndex -1
+	rowGroup [2][]string
+	// Collapsed category groups, keyed by groupKey
+	collapsed map[string]bool
+	// Rolling todos (by ID) whose checklist is expanded
+	expanded map[int]bool
+	// Editing a subtask rather than the todo: index, or -1 for a new one
+	editingSubtask    bool
+	editingSubtaskIdx int
+	// Rolling tab only shows open, unblocked todos
+	readyOnly bool
+	// Projects tab also lists archived projects
+	showArchived bool
+	// Rolling tab shown as a kanban board, with the selected card
+	boardMode bool
+	boardCol  int
+	boardRow  int
+
+	// Tag filter shared by all tabs
+	tagFilter   string
+	filtering   bool
+	filterInput textinput.Model
+
+	// Sort-by menu of the active tab
+	sortMenu   bool
+	sortCursor int
+
+	// Rows marked for bulk actions, by data index on markedTab
+	marked    map[int]bool
+	markedTab int
+	bulkEdit  bool
+
+	// Undo/redo snapshots; saved is the data as last written
+	saved     []byte
+	undoStack []undoEntry
+	redoStack []undoEntry
+	// Trash browser
+	trashOpen   bool
+	trashCursor int
+	// Activity journal browser
+	activityOpen    bool
+	activityEntries []ActivityEntry
+	activityCursor  int
+	// Time tracking report
+	reportOpen bool
+	// Pomodoro timer view and its settings form
+	pomodoroOpen      bool
+	pomodoroCandidate int
+	pomodoroConfig    bool
+	pomodoroInputs    []textinput.Model
+	pomodoroField     int
+	// Stopwatch tab: selected lap and the lap label prompt
+	lapCursor   int
+	lapLabeling bool
+	lapInput    textinput.Model
+	// Modal alerts and backend errors arrive on events; alerts waits to be
+	// dismissed
+	events chan tea.Msg
+	alerts []Notification
+	// Ring the terminal bell with the next frame, see ringBell
+	bell bool
+	// Startup digest of reminders that came due while lif was closed
+	// (indexes into Reminders) and its reschedule prompt
+	missed          []int
+	missedOpen      bool
+	missedCursor    int
+	rescheduling    bool
+	rescheduleInput textinput.Model
+
+	// Shell history import picker (glossary tab)
+	importing    bool
+	importItems  []historyCandidate
+	importMarked map[int]bool
+	importCursor int
 }
 
 // Enhanced styles with better color coding
@@ -144,12 +310,24 @@ func showStatus(msg string, color string) tea.Cmd {
 
 func tickCmd() tea.Cmd {
 	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
-		return tickMsg(t)
+		return tickMsg(readTickClock(t))
 	})
 }
 
+// Text is stored as entered (only trimmed); comparisons and sorting are
+// case-insensitive via lessFold and strings.EqualFold.
 func normalizeText(text string) string {
-	return strings.ToLower(strings.TrimSpace(text))
+	return strings.TrimSpace(text)
+}
+
+// lessFold orders strings case-insensitively, falling back to the original
+// text so that "Git" and "git" still sort deterministically.
+func lessFold(a, b string) bool {
+	la, lb := strings.ToLower(a), strings.ToLower(b)
+	if la != lb {
+		return la < lb
+	}
+	return a < b
 }
 
 func normalizePriority(priority string) string {
@@ -173,12 +351,13 @@ func normalizePriority(priority string) string {
 	}
 }
 
-func parseCountdown(countdownStr string) (time.Time, bool) {
+// countdownDuration reads a countdown like 30m, 2h or 1w
+func countdownDuration(countdownStr string) (time.Duration, bool) {
 	// Days format (1d, 5d, 20d)
 	if strings.HasSuffix(countdownStr, "d") {
 		dayStr := strings.TrimSuffix(countdownStr, "d")
 		if days, err := strconv.Atoi(dayStr); err == nil {
-			return time.Now().Add(time.Duration(days) * 24 * time.Hour), true
+			return time.Duration(days) * 24 * time.Hour, true
 		}
 	}
 
@@ -186,7 +365,7 @@ func parseCountdown(countdownStr string) (time.Time, bool) {
 	if strings.HasSuffix(countdownStr, "w") {
 		weekStr := strings.TrimSuffix(countdownStr, "w")
 		if weeks, err := strconv.Atoi(weekStr); err == nil {
-			return time.Now().Add(time.Duration(weeks) * 7 * 24 * time.Hour), true
+			return time.Duration(weeks) * 7 * 24 * time.Hour, true
 		}
 	}
 
@@ -194,7 +373,7 @@ func parseCountdown(countdownStr string) (time.Time, bool) {
 	if strings.HasSuffix(countdownStr, "m") || strings.HasSuffix(countdownStr, "min") {
 		minStr := strings.TrimSuffix(strings.TrimSuffix(countdownStr, "min"), "m")
 		if minutes, err := strconv.Atoi(minStr); err == nil {
-			return time.Now().Add(time.Duration(minutes) * time.Minute), true
+			return time.Duration(minutes) * time.Minute, true
 		}
 	}
 
@@ -202,7 +381,7 @@ func parseCountdown(countdownStr string) (time.Time, bool) {
 	if strings.HasSuffix(countdownStr, "h") || strings.HasSuffix(countdownStr, "hr") {
 		hourStr := strings.TrimSuffix(strings.TrimSuffix(countdownStr, "hr"), "h")
 		if hours, err := strconv.Atoi(hourStr); err == nil {
-			return time.Now().Add(time.Duration(hours) * time.Hour), true
+			return time.Duration(hours) * time.Hour, true
 		}
 	}
 
@@ -210,11 +389,11 @@ func parseCountdown(countdownStr string) (time.Time, bool) {
 	if strings.HasSuffix(countdownStr, "s") || strings.HasSuffix(countdownStr, "sec") {
 		secStr := strings.TrimSuffix(strings.TrimSuffix(countdownStr, "sec"), "s")
 		if seconds, err := strconv.Atoi(secStr); err == nil {
-			return time.Now().Add(time.Duration(seconds) * time.Second), true
+			return time.Duration(seconds) * time.Second, true
 		}
 	}
 
-	return time.Time{}, false
+	return 0, false
 }
 
 func parseAlarmTime(alarmStr string) (time.Time, bool) {
@@ -293,85 +472,6 @@ func isWSL() bool {
 	return false
 }
 
-func playNotificationSound() {
-	// Try both mp3 and wav files
-	soundFiles := []string{"assets/notification.mp3", "assets/notification.wav"}
-	var soundFile string
-	for _, file := range soundFiles {
-		if _, err := os.Stat(file); err == nil {
-			soundFile = file
-			break
-		}
-	}
-
-	// If no sound file exists, play system beep
-	if soundFile == "" {
-		if isWSL() {
-			go exec.Command("powershell.exe", "-Command", "[console]::beep(800,200)").Run()
-		} else {
-			go exec.Command("printf", "\a").Run()
-		}
-		return
-	}
-
-	if isWSL() {
-		// In WSL, just use Linux audio players if available
-		players := [][]string{
-			{"mpv", "--no-video", "--really-quiet", "--audio-buffer=1.0", soundFile},
-			{"vlc", "--intf", "dummy", "--play-and-exit", soundFile},
-			{"mplayer", "-really-quiet", soundFile},
-			{"ffplay", "-nodisp", "-autoexit", "-v", "quiet", soundFile},
-		}
-		for _, cmd := range players {
-			if _, err := exec.LookPath(cmd[0]); err == nil {
-				go exec.Command(cmd[0], cmd[1:]...).Run()
-				return
-			}
-		}
-		// If no players available, just beep
-		go exec.Command("powershell.exe", "-Command", "[console]::beep(800,200)").Run()
-		return
-	}
-
-	switch runtime.GOOS {
-	case "linux":
-		// Try different audio players (in order of preference)
-		players := [][]string{
-			{"mpv", "--no-video", "--really-quiet", "--audio-buffer=1.0", soundFile},
-			{"vlc", "--intf", "dummy", "--play-and-exit", soundFile},
-			{"mplayer", "-really-quiet", soundFile},
-			{"ffplay", "-nodisp", "-autoexit", "-v", "quiet", soundFile},
-		}
-		for _, cmd := range players {
-			if _, err := exec.LookPath(cmd[0]); err == nil {
-				go exec.Command(cmd[0], cmd[1:]...).Run()
-				return
-			}
-		}
-	case "darwin":
-		// Use afplay on macOS
-		go exec.Command("afplay", soundFile).Run()
-	case "windows":
-		// Use PowerShell to play sound on Windows
-		go exec.Command("powershell", "-Command", fmt.Sprintf(`(New-Object Media.SoundPlayer "%s").PlaySync()`, soundFile)).Run()
-	}
-}
-
-func sendNotification(title, message string) {
-	// Play notification sound
-	playNotificationSound()
-
-	// Send system notification
-	switch runtime.GOOS {
-	case "linux":
-		exec.Command("notify-send", title, message).Run()
-	case "darwin":
-		exec.Command("osascript", "-e", fmt.Sprintf(`display notification "%s" with title "%s"`, message, title)).Run()
-	case "windows":
-		exec.Command("powershell", "-Command", fmt.Sprintf(`[System.Reflection.Assembly]::LoadWithPartialName('System.Windows.Forms'); [System.Windows.Forms.MessageBox]::Show('%s', '%s')`, message, title)).Run()
-	}
-}
-
 func getMostRecent3AM() time.Time {
 	now := time.Now()
 	today3AM := time.Date(now.Year(), now.Month(), now.Day(), 3, 0, 0, 0, now.Location())
@@ -402,54 +502,262 @@ func resetDailyTasks(data *AppData) bool {
 	return resetOccurred
 }
 
-func sortItems(items interface{}, sortBy string) {
-	switch v := items.(type) {
-	case []Daily:
-		sort.Slice(v, func(i, j int) bool {
-			if v[i].Category != v[j].Category {
-				return strings.ToLower(v[i].Category) < strings.ToLower(v[j].Category)
+// SortPref is the sort order chosen for a tab: Key is "" for the default
+// order, "manual" for the order set with shift+j/k, or a column key.
+type SortPref struct {
+	Key  string `json:"key"`
+	Desc bool   `json:"desc,omitempty"`
+}
+
+var priorityRank = map[string]int{"HIGH": 0, "MEDIUM": 1, "LOW": 2}
+
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+func compareFold(a, b string) int {
+	if strings.EqualFold(a, b) {
+		return 0
+	}
+	if lessFold(a, b) {
+		return -1
+	}
+	return 1
+}
+
+// compareOrder compares manual positions; items never placed (0) go last
+func compareOrder(a, b int) int {
+	if (a == 0) != (b == 0) {
+		if a == 0 {
+			return 1
+		}
+		return -1
+	}
+	return compareInt(a, b)
+}
+
+// percent is done/total as a whole percentage, 0 when there is nothing to do
+func percent(done, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return done * 100 / total
+}
+
+// reminderRemaining is how long until a reminder fires, for sorting by time
+func reminderRemaining(reminder Reminder) time.Duration {
+	if reminder.Status == "paused" {
+		return reminder.PausedRemaining
+	}
+	if reminder.TargetTime.IsZero() {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Until(reminder.TargetTime)
+}
+
+// sortItems returns the indices of a tab's items in display order. The data
+// itself is left in place, so indices stay valid between renders.
+func sortItems(data *AppData, tab int, pref SortPref) []int {
+	var n int
+	var byDefault, byKey func(i, j int) int
+	var order func(i int) int
+
+	switch tab {
+	case 2: // Dailies
+		v := data.Dailies
+		n = len(v)
+		byDefault = func(i, j int) int {
+			if c := compareFold(v[i].Category, v[j].Category); c != 0 {
+				return c
 			}
-			pri := map[string]int{"HIGH": 0, "MEDIUM": 1, "LOW": 2}
-			if pri[v[i].Priority] != pri[v[j].Priority] {
-				return pri[v[i].Priority] < pri[v[j].Priority]
+			if c := compareInt(priorityRank[v[i].Priority], priorityRank[v[j].Priority]); c != 0 {
+				return c
 			}
-			return v[i].Task < v[j].Task
-		})
-	case []RollingTodo:
-		sort.Slice(v, func(i, j int) bool {
-			if v[i].Category != v[j].Category {
-				return strings.ToLower(v[i].Category) < strings.ToLower(v[j].Category)
+			return compareFold(v[i].Task, v[j].Task)
+		}
+		byKey = func(i, j int) int {
+			switch pref.Key {
+			case "task":
+				return compareFold(v[i].Task, v[j].Task)
+			case "priority":
+				return compareInt(priorityRank[v[i].Priority], priorityRank[v[j].Priority])
+			case "category":
+				return compareFold(v[i].Category, v[j].Category)
+			case "deadline":
+				return compareFold(v[i].Deadline, v[j].Deadline)
+			case "status":
+				return compareFold(v[i].Status, v[j].Status)
 			}
-			pri := map[string]int{"HIGH": 0, "MEDIUM": 1, "LOW": 2}
-			if pri[v[i].Priority] != pri[v[j].Priority] {
-				return pri[v[i].Priority] < pri[v[j].Priority]
+			return 0
+		}
+		order = func(i int) int { return v[i].Order }
+	case 3: // Rolling Todos
+		v := data.RollingTodos
+		n = len(v)
+		byDefault = func(i, j int) int {
+			if c := compareFold(v[i].Category, v[j].Category); c != 0 {
+				return c
 			}
-			return v[i].Task < v[j].Task
-		})
-	case []Reminder:
-		sort.Slice(v, func(i, j int) bool {
+			if c := compareInt(priorityRank[v[i].Priority], priorityRank[v[j].Priority]); c != 0 {
+				return c
+			}
+			return compareFold(v[i].Task, v[j].Task)
+		}
+		progress := func(todo RollingTodo) int {
+			done := 0
+			for _, sub := range todo.Subtasks {
+				if sub.Done {
+					done++
+				}
+			}
+			return percent(done, len(todo.Subtasks))
+		}
+		byKey = func(i, j int) int {
+			switch pref.Key {
+			case "id":
+				return compareInt(v[i].ID, v[j].ID)
+			case "task":
+				return compareFold(v[i].Task, v[j].Task)
+			case "priority":
+				return compareInt(priorityRank[v[i].Priority], priorityRank[v[j].Priority])
+			case "category":
+				return compareFold(v[i].Category, v[j].Category)
+			case "project":
+				return compareFold(projectName(data.Projects, v[i].ProjectID), projectName(data.Projects, v[j].ProjectID))
+			case "deadline":
+				return compareFold(v[i].Deadline, v[j].Deadline)
+			case "progress":
+				return compareInt(progress(v[i]), progress(v[j]))
+			}
+			return 0
+		}
+		order = func(i int) int { return v[i].Order }
+	case 4: // Reminders
+		v := data.Reminders
+		n = len(v)
+		statusOrder := map[string]int{"active": 0, "pending": 1, "completed": 2, "expired": 3}
+		byDefault = func(i, j int) int {
 			if v[i].Status != v[j].Status {
-				statusOrder := map[string]int{"active": 0, "pending": 1, "completed": 2, "expired": 3}
-				return statusOrder[v[i].Status] < statusOrder[v[j].Status]
+				return compareInt(statusOrder[v[i].Status], statusOrder[v[j].Status])
 			}
-			return v[i].Reminder < v[j].Reminder
-		})
-	case []GlossaryItem:
-		sort.Slice(v, func(i, j int) bool {
-			if v[i].Lang != v[j].Lang {
-				return v[i].Lang < v[j].Lang
+			return compareFold(v[i].Reminder, v[j].Reminder)
+		}
+		byKey = func(i, j int) int {
+			switch pref.Key {
+			case "reminder":
+				return compareFold(v[i].Reminder, v[j].Reminder)
+			case "note":
+				return compareFold(v[i].Note, v[j].Note)
+			case "time":
+				return compareInt(int(reminderRemaining(v[i])), int(reminderRemaining(v[j])))
+			case "status":
+				return compareInt(statusOrder[v[i].Status], statusOrder[v[j].Status])
 			}
-			return v[i].Command < v[j].Command
-		})
+			return 0
+		}
+		order = func(i int) int { return v[i].Order }
+	case 5: // Glossary
+		v := data.Glossary
+		n = len(v)
+		byDefault = func(i, j int) int {
+			if c := compareFold(v[i].Lang, v[j].Lang); c != 0 {
+				return c
+			}
+			return compareFold(v[i].Command, v[j].Command)
+		}
+		byKey = func(i, j int) int {
+			switch pref.Key {
+			case "lang":
+				return compareFold(v[i].Lang, v[j].Lang)
+			case "command":
+				return compareFold(v[i].Command, v[j].Command)
+			case "usage":
+				return compareFold(v[i].Usage, v[j].Usage)
+			case "example":
+				return compareFold(v[i].Example, v[j].Example)
+			case "meaning":
+				return compareFold(v[i].Meaning, v[j].Meaning)
+			}
+			return 0
+		}
+		order = func(i int) int { return v[i].Order }
+	case 6: // Projects
+		v := data.Projects
+		n = len(v)
+		statusOrder := map[string]int{"active": 0, "on-hold": 1, "done": 2}
+		byDefault = func(i, j int) int {
+			if v[i].Status != v[j].Status {
+				return compareInt(statusOrder[v[i].Status], statusOrder[v[j].Status])
+			}
+			return compareFold(v[i].Name, v[j].Name)
+		}
+		byKey = func(i, j int) int {
+			switch pref.Key {
+			case "name":
+				return compareFold(v[i].Name, v[j].Name)
+			case "status":
+				return compareInt(statusOrder[v[i].Status], statusOrder[v[j].Status])
+			case "due":
+				return compareFold(v[i].Due, v[j].Due)
+			case "progress":
+				iDone, iTotal := projectProgress(data.RollingTodos, v[i].ID)
+				jDone, jTotal := projectProgress(data.RollingTodos, v[j].ID)
+				return compareInt(percent(iDone, iTotal), percent(jDone, jTotal))
+			}
+			return 0
+		}
+		order = func(i int) int { return v[i].Order }
 	}
+
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		i, j := indices[a], indices[b]
+		var c int
+		switch pref.Key {
+		case "":
+			c = byDefault(i, j)
+		case "manual":
+			c = compareOrder(order(i), order(j))
+		default:
+			c = byKey(i, j)
+		}
+		if pref.Desc {
+			c = -c
+		}
+		// Ties fall back to the default order so equal keys stay grouped
+		if c == 0 && pref.Key != "" {
+			c = byDefault(i, j)
+		}
+		return c < 0
+	})
+	return indices
 }
 
 func initialModel() model {
 	m := model{
 		activeTab:   1,
 		data:        loadData(),
+		expanded:    map[int]bool{},
+		collapsed:   map[string]bool{},
+		marked:      map[int]bool{},
 		statusColor: "86",
-		lastTick:    time.Now(),
+		lastTick:    readTickClock(time.Now()),
+		events:      make(chan tea.Msg, 16),
+	}
+
+	if note := migrateData(&m.data); note != "" {
+		m.statusMsg = note
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(10 * time.Second)
+		saveData(m.data)
 	}
 
 	// Check for daily task reset on startup
@@ -457,20 +765,35 @@ func initialModel() model {
 		saveData(m.data)
 	}
 
+	// Drop `lif ack` entries that were applied or whose reminder is gone
+	pruneAcknowledgements(loadAcknowledgements(), m.data.Reminders)
+
 	m.setupTables()
+	m.mergeImports()
+	m.catchUp(time.Now())
+	m.catchUpPomodoro(time.Now())
+	m.saved = snapshot(m.data)
 	return m
 }
 
+// migrateData upgrades data saved by older versions and returns a one-time
+// note for the user, or "" when nothing changed.
+func migrateData(data *AppData) string {
+	note := ""
+	if data.Version < 1 {
+		// Version 0 lowercased all text on save; the original case is lost
+		if len(data.Dailies)+len(data.RollingTodos)+len(data.Reminders)+len(data.Glossary) > 0 {
+			note = "ℹ️ Text now keeps its case. Older entries were saved lowercase; edit them to restore it"
+		}
+		data.Version = 1
+	}
+	return note
+}
+
 func (m *model) setupTables() {
 	// Tab 2: Dailies
 	m.tables[0] = table.New(
-		table.WithColumns([]table.Column{
-			{Title: "Task", Width: 30},
-			{Title: "Priority", Width: 10},
-			{Title: "Category", Width: 15},
-			{Title: "Deadline", Width: 12},
-			{Title: "Status", Width: 25},
-		}),
+		table.WithColumns(dailyColumns),
 		table.WithRows(m.dailyRows()),
 		table.WithFocused(true),
 		table.WithHeight(15),
@@ -478,12 +801,7 @@ func (m *model) setupTables() {
 
 	// Tab 3: Rolling Todos
 	m.tables[1] = table.New(
-		table.WithColumns([]table.Column{
-			{Title: "Task", Width: 40},
-			{Title: "Priority", Width: 10},
-			{Title: "Category", Width: 15},
-			{Title: "Deadline", Width: 15},
-		}),
+		table.WithColumns(rollingColumns),
 		table.WithRows(m.rollingRows()),
 		table.WithFocused(true),
 		table.WithHeight(15),
@@ -495,6 +813,7 @@ func (m *model) setupTables() {
 			{Title: "Reminder", Width: 30},
 			{Title: "Note", Width: 35},
 			{Title: "Alarm/Countdown", Width: 35},
+			{Title: "Tags", Width: 20},
 		}),
 		table.WithRows(m.reminderRows()),
 		table.WithFocused(true),
@@ -509,12 +828,27 @@ func (m *model) setupTables() {
 			{Title: "Usage", Width: 25},
 			{Title: "Example", Width: 25},
 			{Title: "Meaning", Width: 25},
+			{Title: "Tags", Width: 15},
 		}),
 		table.WithRows(m.glossaryRows()),
 		table.WithFocused(true),
 		table.WithHeight(15),
 	)
 
+	// Tab 6: Projects
+	m.tables[4] = table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Project", Width: projectNameWidth},
+			{Title: "Status", Width: 10},
+			{Title: "Due", Width: 12},
+			{Title: "Progress", Width: 16},
+			{Title: "Description", Width: 40},
+		}),
+		table.WithRows(m.projectRows()),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+
 	// Apply modern table styles
 	s := table.DefaultStyles()
 	s.Header = s.Header.
@@ -547,12 +881,63 @@ func (m *model) adjustLayout() {
 	for i := range m.tables {
 		m.tables[i].SetHeight(tableHeight)
 	}
+
+	// Leave room for the glossary detail pane when it sits below the table
+	if !m.glossaryDetailSide() {
+		glossaryHeight := tableHeight - glossaryDetailHeight
+		if glossaryHeight < 5 {
+			glossaryHeight = 5
+		}
+		m.tables[3].SetHeight(glossaryHeight)
+	}
+}
+
+// selectedIndex maps the cursor of a table to an index into its data slice,
+// or -1 when the table is empty.
+func (m *model) selectedIndex(tableIdx int) int {
+	cursor := m.tables[tableIdx].Cursor()
+	if cursor < 0 || cursor >= len(m.rowIndex[tableIdx]) {
+		return -1
+	}
+	return m.rowIndex[tableIdx][cursor]
+}
+
+func (m *model) refreshTables() {
+	m.tables[0].SetRows(m.dailyRows())
+	m.tables[1].SetRows(m.rollingRows())
+	m.tables[2].SetRows(m.reminderRows())
+	m.tables[3].SetRows(m.glossaryRows())
+	m.tables[4].SetRows(m.projectRows())
 }
 
 func (m *model) dailyRows() []table.Row {
 	rows := []table.Row{}
-	sortItems(m.data.Dailies, "category")
-	for _, daily := range m.data.Dailies {
+	m.rowIndex[0] = nil
+	m.rowGroup[0] = nil
+	visible := []int{}
+	for _, i := range sortItems(&m.data, 2, m.sortPref(2)) {
+		if m.matchesTagFilter(m.data.Dailies[i].Tags) {
+			visible = append(visible, i)
+		}
+	}
+
+	category := func(i int) string { return m.data.Dailies[i].Category }
+	grouped := m.groupedByCategory(2)
+	counts, completed := groupStats(visible, category, func(i int) bool { return m.data.Dailies[i].Status == "DONE" })
+	for n, i := range visible {
+		daily := m.data.Dailies[i]
+		if grouped && startsGroup(visible, n, category) {
+			key := strings.ToLower(daily.Category)
+			rows = append(rows, m.dailyGroupRow(daily.Category, counts[key], completed[key]))
+			m.rowIndex[0] = append(m.rowIndex[0], -1)
+			m.rowGroup[0] = append(m.rowGroup[0], daily.Category)
+		}
+		if grouped && m.collapsed[groupKey(2, daily.Category)] {
+			continue
+		}
+		m.rowIndex[0] = append(m.rowIndex[0], i)
+		m.rowGroup[0] = append(m.rowGroup[0], daily.Category)
+
 		priority := daily.Priority
 		if priority == "" {
 			priority = "MEDIUM"
@@ -580,12 +965,19 @@ func (m *model) dailyRows() []table.Row {
 			status = statusOverdueStyle.Render("INCOMPLETE")
 		}
 
+		// The group header already names the category
+		categoryCell := daily.Category
+		if grouped {
+			categoryCell = ""
+		}
+
 		rows = append(rows, table.Row{
-			normalizeText(daily.Task),
+			m.markCell(2, i, daily.Task),
 			displayPriority,
-			normalizeText(daily.Category),
+			categoryCell,
 			daily.Deadline,
 			status,
+			formatTags(daily.Tags),
 		})
 	}
 	return rows
@@ -593,8 +985,44 @@ func (m *model) dailyRows() []table.Row {
 
 func (m *model) rollingRows() []table.Row {
 	rows := []table.Row{}
-	sortItems(m.data.RollingTodos, "category")
-	for _, todo := range m.data.RollingTodos {
+	m.rowIndex[1] = nil
+	m.rowSubtask = nil
+	m.rowGroup[1] = nil
+	visible := []int{}
+	for _, i := range sortItems(&m.data, 3, m.sortPref(3)) {
+		todo := m.data.RollingTodos[i]
+		if !m.matchesTagFilter(todo.Tags) {
+			continue
+		}
+		if projectArchived(m.data.Projects, todo.ProjectID) {
+			continue
+		}
+		if m.readyOnly && (todo.Done || isBlocked(todo, m.data.RollingTodos)) {
+			continue
+		}
+		visible = append(visible, i)
+	}
+
+	category := func(i int) string { return m.data.RollingTodos[i].Category }
+	grouped := m.groupedByCategory(3)
+	counts, completed := groupStats(visible, category, func(i int) bool { return m.data.RollingTodos[i].Done })
+	for n, i := range visible {
+		todo := m.data.RollingTodos[i]
+		if grouped && startsGroup(visible, n, category) {
+			key := strings.ToLower(todo.Category)
+			rows = append(rows, m.rollingGroupRow(todo.Category, counts[key], completed[key]))
+			m.rowIndex[1] = append(m.rowIndex[1], -1)
+			m.rowSubtask = append(m.rowSubtask, -1)
+			m.rowGroup[1] = append(m.rowGroup[1], todo.Category)
+		}
+		if grouped && m.collapsed[groupKey(3, todo.Category)] {
+			continue
+		}
+		blocked := isBlocked(todo, m.data.RollingTodos)
+		m.rowIndex[1] = append(m.rowIndex[1], i)
+		m.rowSubtask = append(m.rowSubtask, -1)
+		m.rowGroup[1] = append(m.rowGroup[1], todo.Category)
+
 		priority := todo.Priority
 		if priority == "" {
 			priority = "MEDIUM"
@@ -614,20 +1042,61 @@ func (m *model) rollingRows() []table.Row {
 			displayPriority = "MEDIUM"
 		}
 
-		rows = append(rows, table.Row{
-			normalizeText(todo.Task),
+		task := m.markCell(3, i, todo.Task)
+		if len(todo.Subtasks) > 0 {
+			if m.expanded[todo.ID] {
+				task = "▾ " + task
+			} else {
+				task = "▸ " + task
+			}
+		}
+
+		// The group header already names the category
+		categoryCell := todo.Category
+		if grouped {
+			categoryCell = ""
+		}
+
+		row := table.Row{
+			strconv.Itoa(todo.ID),
+			task,
 			displayPriority,
-			normalizeText(todo.Category),
+			categoryCell,
+			projectName(m.data.Projects, todo.ProjectID),
 			todo.Deadline,
-		})
+			todoProgress(todo),
+			formatDependencies(todo.BlockedBy),
+			formatTags(todo.Tags),
+		}
+		if blocked {
+			for c := range row {
+				row[c] = fitStyled(blockedStyle, row[c], rollingColumns[c].Width)
+			}
+		}
+		rows = append(rows, row)
+
+		if m.expanded[todo.ID] {
+			for j, sub := range todo.Subtasks {
+				rows = append(rows, subtaskRow(sub, j == len(todo.Subtasks)-1))
+				m.rowIndex[1] = append(m.rowIndex[1], i)
+				m.rowSubtask = append(m.rowSubtask, j)
+				m.rowGroup[1] = append(m.rowGroup[1], todo.Category)
+			}
+		}
 	}
 	return rows
 }
 
 func (m *model) reminderRows() []table.Row {
 	rows := []table.Row{}
-	sortItems(m.data.Reminders, "status")
-	for _, reminder := range m.data.Reminders {
+	m.rowIndex[2] = nil
+	for _, i := range sortItems(&m.data, 4, m.sortPref(4)) {
+		reminder := m.data.Reminders[i]
+		if !m.matchesTagFilter(reminder.Tags) {
+			continue
+		}
+		m.rowIndex[2] = append(m.rowIndex[2], i)
+
 		// Display countdown/alarm time
 		displayTime := reminder.AlarmOrCountdown
 		if reminder.Status == "paused" && reminder.PausedRemaining > 0 {
@@ -645,15 +1114,24 @@ func (m *model) reminderRows() []table.Row {
 				} else {
 					displayTime = fmt.Sprintf("%s (%s)", reminder.AlarmOrCountdown, reminder.TargetTime.Format("15:04"))
 				}
+				if reminder.inLeadWindow(time.Now()) {
+					displayTime += " ⏰"
+				}
 			} else {
 				displayTime = fmt.Sprintf("%s (EXPIRED)", reminder.AlarmOrCountdown)
+				if reminder.nagging() {
+					displayTime = fmt.Sprintf("%s (EXPIRED 🔁 %d)", reminder.AlarmOrCountdown, reminder.Nags)
+				} else if reminder.acknowledged() {
+					displayTime = fmt.Sprintf("%s (EXPIRED ✓)", reminder.AlarmOrCountdown)
+				}
 			}
 		}
 
 		rows = append(rows, table.Row{
-			normalizeText(reminder.Reminder),
-			normalizeText(reminder.Note),
+			m.markCell(4, i, reminder.Reminder),
+			reminder.Note,
 			displayTime,
+			formatTags(reminder.Tags),
 		})
 	}
 	return rows
@@ -661,30 +1139,130 @@ func (m *model) reminderRows() []table.Row {
 
 func (m *model) glossaryRows() []table.Row {
 	rows := []table.Row{}
-	sortItems(m.data.Glossary, "lang")
-	for _, item := range m.data.Glossary {
+	m.rowIndex[3] = nil
+	for _, i := range sortItems(&m.data, 5, m.sortPref(5)) {
+		item := m.data.Glossary[i]
+		if !m.matchesTagFilter(item.Tags) {
+			continue
+		}
+		m.rowIndex[3] = append(m.rowIndex[3], i)
+		// Imported commands and examples can span lines, which would break
+		// the table; the detail pane shows them as they are
 		rows = append(rows, table.Row{
-			normalizeText(item.Lang),
-			normalizeText(item.Command),
-			normalizeText(item.Usage),
-			normalizeText(item.Example),
-			normalizeText(item.Meaning),
+			m.markCell(5, i, item.Lang),
+			oneLine(item.Command),
+			oneLine(item.Usage),
+			oneLine(item.Example),
+			oneLine(item.Meaning),
+			formatTags(item.Tags),
 		})
 	}
 	return rows
 }
 
-func (m *model) toggleReminderStatus(action string) {
-	if m.activeTab != 4 || len(m.data.Reminders) == 0 {
-		return
+// glossaryDetailSide reports whether the detail pane fits next to the glossary
+// table; narrower terminals get it below the table instead.
+func (m *model) glossaryDetailSide() bool {
+	return m.width >= 170
+}
+
+func (m *model) glossaryDetail() string {
+	idx := m.selectedIndex(3)
+	if idx == -1 {
+		return ""
 	}
+	item := m.data.Glossary[idx]
 
-	cursor := m.tables[2].Cursor()
-	if cursor >= len(m.data.Reminders) {
-		return
+	width := 100
+	if m.width > 0 {
+		width = m.width - 4
+	}
+	if m.glossaryDetailSide() {
+		width = m.width - 135
+	}
+
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86"))
+	textStyle := lipgloss.NewStyle().Width(width - 4)
+
+	sections := []string{}
+	title := hlCommandStyle.Render(item.Lang)
+	if item.Lang == "" {
+		title = bulletStyle.Render("(no lang)")
+	}
+	if len(item.Tags) > 0 {
+		title += " " + renderTags(item.Tags)
+	}
+	sections = append(sections, title)
+	if item.Command != "" {
+		sections = append(sections, labelStyle.Render("Command")+"\n"+textStyle.Render(highlightCode(item.Lang, item.Command)))
+	}
+	if item.Usage != "" {
+		sections = append(sections, labelStyle.Render("Usage")+"\n"+textStyle.Render(highlightCode(item.Lang, item.Usage)))
+	}
+	if item.Example != "" {
+		sections = append(sections, labelStyle.Render("Example")+"\n"+textStyle.Render(highlightCode(item.Lang, item.Example)))
+	}
+	if item.Meaning != "" {
+		sections = append(sections, labelStyle.Render("Meaning")+"\n"+textStyle.Render(item.Meaning))
 	}
 
-	reminder := &m.data.Reminders[cursor]
+	paneStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1).
+		Width(width - 2)
+	if !m.glossaryDetailSide() {
+		paneStyle = paneStyle.MaxHeight(glossaryDetailHeight)
+	}
+	return paneStyle.Render(strings.Join(sections, "\n\n"))
+}
+
+func nextDailyID(dailies []Daily) int {
+	maxID := 0
+	for _, daily := range dailies {
+		if daily.ID > maxID {
+			maxID = daily.ID
+		}
+	}
+	return maxID + 1
+}
+
+func nextGlossaryID(glossary []GlossaryItem) int {
+	maxID := 0
+	for _, item := range glossary {
+		if item.ID > maxID {
+			maxID = item.ID
+		}
+	}
+	return maxID + 1
+}
+
+func nextReminderID(reminders []Reminder) int {
+	maxID := 0
+	for _, reminder := range reminders {
+		if reminder.ID > maxID {
+			maxID = reminder.ID
+		}
+	}
+	return maxID + 1
+}
+
+// uniqueReminderIDs renumbers reminders whose ID is missing or already taken.
+// Older versions numbered new reminders by count, which repeats IDs after a
+// delete, and acknowledgements find their reminder by ID.
+func uniqueReminderIDs(reminders []Reminder) {
+	seen := map[int]bool{}
+	for i := range reminders {
+		if reminders[i].ID <= 0 || seen[reminders[i].ID] {
+			reminders[i].ID = nextReminderID(reminders)
+		}
+		seen[reminders[i].ID] = true
+	}
+}
+
+// applyReminderAction starts, pauses or resets a reminder and returns the
+// status line describing what happened
+func applyReminderAction(reminder *Reminder, action string) (string, string) {
 	var statusMsg string
 	var statusColor string
 
@@ -692,7 +1270,10 @@ func (m *model) toggleReminderStatus(action string) {
 	case "start":
 		if reminder.Status == "paused" {
 			// Resume from paused state
-			if reminder.PausedRemaining > 0 {
+			if reminder.IsCountdown && reminder.Duration > 0 {
+				reminder.resumeCountdown(time.Now())
+				reminder.PausedRemaining = 0
+			} else if reminder.PausedRemaining > 0 {
 				reminder.TargetTime = time.Now().Add(reminder.PausedRemaining)
 				reminder.PausedRemaining = 0
 			}
@@ -704,9 +1285,8 @@ func (m *model) toggleReminderStatus(action string) {
 			reminder.Status = "active"
 			reminder.Notified = false
 			// Re-parse the alarm/countdown
-			if targetTime, isCountdown := parseCountdown(reminder.AlarmOrCountdown); isCountdown {
-				reminder.TargetTime = targetTime
-				reminder.IsCountdown = true
+			if d, isCountdown := countdownDuration(reminder.AlarmOrCountdown); isCountdown {
+				reminder.startCountdown(d, time.Now())
 			} else if targetTime, isAlarm := parseAlarmTime(reminder.AlarmOrCountdown); isAlarm {
 				reminder.TargetTime = targetTime
 				reminder.IsCountdown = false
@@ -721,7 +1301,9 @@ func (m *model) toggleReminderStatus(action string) {
 	case "pause":
 		if reminder.Status == "active" {
 			// Store remaining time when pausing
-			if !reminder.TargetTime.IsZero() {
+			if reminder.IsCountdown && reminder.Duration > 0 {
+				reminder.pauseCountdown()
+			} else if !reminder.TargetTime.IsZero() {
 				reminder.PausedRemaining = time.Until(reminder.TargetTime)
 				if reminder.PausedRemaining < 0 {
 					reminder.PausedRemaining = 0
@@ -740,9 +1322,8 @@ func (m *model) toggleReminderStatus(action string) {
 		reminder.Notified = false
 		reminder.PausedRemaining = 0 // Clear any paused time
 		// Re-parse and reset the target time
-		if targetTime, isCountdown := parseCountdown(reminder.AlarmOrCountdown); isCountdown {
-			reminder.TargetTime = targetTime
-			reminder.IsCountdown = true
+		if d, isCountdown := countdownDuration(reminder.AlarmOrCountdown); isCountdown {
+			reminder.startCountdown(d, time.Now())
 		} else if targetTime, isAlarm := parseAlarmTime(reminder.AlarmOrCountdown); isAlarm {
 			reminder.TargetTime = targetTime
 			reminder.IsCountdown = false
@@ -750,9 +1331,27 @@ func (m *model) toggleReminderStatus(action string) {
 		statusMsg = fmt.Sprintf("🔄 Reset: %s", reminder.Reminder)
 		statusColor = "82"
 	}
+	return statusMsg, statusColor
+}
+
+func (m *model) toggleReminderStatus(action string) {
+	if m.activeTab != 4 || len(m.data.Reminders) == 0 {
+		return
+	}
+
+	cursor := m.selectedIndex(2)
+	if cursor == -1 {
+		return
+	}
+
+	before := m.data.Reminders[cursor]
+	statusMsg, statusColor := applyReminderAction(&m.data.Reminders[cursor], action)
+	if m.data.Reminders[cursor].Status != before.Status || action == "reset" {
+		m.logItem(reminderEvents[action], 4, cursor)
+	}
 
 	m.tables[2].SetRows(m.reminderRows())
-	saveData(m.data)
+	m.save("reminder " + action)
 	m.statusMsg = statusMsg
 	m.statusColor = statusColor
 	m.statusExpiry = time.Now().Add(3 * time.Second)
@@ -763,8 +1362,8 @@ func (m *model) toggleCompletion() {
 		return
 	}
 
-	cursor := m.tables[0].Cursor()
-	if cursor >= len(m.data.Dailies) {
+	cursor := m.selectedIndex(0)
+	if cursor == -1 {
 		return
 	}
 
@@ -781,8 +1380,13 @@ func (m *model) toggleCompletion() {
 	}
 
 	m.data.Dailies[cursor].Status = newStatus
+	if newStatus == "DONE" {
+		m.logItem("completed", 2, cursor)
+	} else {
+		m.logItem("reopened", 2, cursor)
+	}
 	m.tables[0].SetRows(m.dailyRows())
-	saveData(m.data)
+	m.save("toggle")
 
 	statusColor := "86"
 	if newStatus == "DONE" {
@@ -796,7 +1400,7 @@ func (m *model) toggleCompletion() {
 }
 
 func (m model) Init() tea.Cmd {
-	return tickCmd()
+	return tea.Batch(tickCmd(), waitForEvent(m.events))
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -807,30 +1411,55 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusExpiry = time.Now().Add(3 * time.Second)
 		return m, nil
 
+	case alertMsg:
+		m.alerts = append(m.alerts, Notification(msg))
+		return m, waitForEvent(m.events)
+
+	case bellMsg:
+		m.ringBell()
+		return m, waitForEvent(m.events)
+
+	case notifyErrorMsg:
+		m.statusMsg = fmt.Sprintf("❌ %s notification failed: %v", msg.backend, msg.err)
+		m.statusColor = "196"
+		m.statusExpiry = time.Now().Add(5 * time.Second)
+		return m, waitForEvent(m.events)
+
 	case tickMsg:
-		m.lastTick = time.Time(msg)
+		m.bell = false
+		m.advanceCountdowns(m.lastTick, tickClock(msg))
+		m.lastTick = tickClock(msg)
 
 		// Check for daily task reset (runs every tick but only resets when needed)
 		if resetDailyTasks(&m.data) {
 			m.tables[0].SetRows(m.dailyRows())
+			m.logActivity("reset", "daily", 0, "daily tasks reset at 3AM")
 			m.statusMsg = "🌅 Daily tasks reset at 3AM"
 			m.statusColor = "82"
 			m.statusExpiry = time.Now().Add(5 * time.Second)
-			saveData(m.data)
+			m.save("daily reset")
 		}
 
 		// Check for reminder notifications (only for active reminders)
 		for i, reminder := range m.data.Reminders {
-			if !reminder.TargetTime.IsZero() && !reminder.Notified && reminder.Status == "active" && time.Now().After(reminder.TargetTime) {
+			if !reminder.TargetTime.IsZero() && !reminder.Notified && reminder.Status == "active" && time.Now().After(reminder.TargetTime) && !m.isMissed(i) {
 				m.data.Reminders[i].Notified = true
 				m.data.Reminders[i].Status = "expired"
-				sendNotification("Reminder", reminder.Reminder)
+				m.data.Reminders[i].LastNotified = time.Now()
+				m.data.Reminders[i].Nags = 0
+				m.logItem("fired", 4, i)
+				m.notify(reminder.Notify, reminder.Sound, Notification{Title: "Reminder", Message: reminder.Reminder, ReminderID: reminder.ID, BreakThrough: reminder.BreakDND})
 				m.statusMsg = fmt.Sprintf("🔔 Reminder: %s", reminder.Reminder)
 				m.statusColor = "226"
 				m.statusExpiry = time.Now().Add(5 * time.Second)
-				saveData(m.data)
+				m.saveQuiet()
 			}
 		}
+		m.mergeImports()
+		m.checkLeads(time.Now())
+		m.checkNags(time.Now())
+		m.checkPomodoro(time.Now())
+		m.checkDND(time.Now())
 		m.tables[2].SetRows(m.reminderRows())
 		return m, tickCmd()
 
@@ -841,9 +1470,45 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if len(m.alerts) > 0 {
+			return m.handleAlertKeys(msg)
+		}
+		if m.missedOpen {
+			return m.handleMissedKeys(msg)
+		}
 		if m.editing {
 			return m.handleEditingKeys(msg)
 		}
+		if m.importing {
+			return m.handleImportKeys(msg)
+		}
+		if m.filtering {
+			return m.handleFilterKeys(msg)
+		}
+		if m.sortMenu {
+			return m.handleSortMenuKeys(msg)
+		}
+		if m.trashOpen {
+			return m.handleTrashKeys(msg)
+		}
+		if m.activityOpen {
+			return m.handleActivityKeys(msg)
+		}
+		if m.reportOpen {
+			return m.handleReportKeys(msg)
+		}
+		if m.pomodoroOpen {
+			return m.handlePomodoroKeys(msg)
+		}
+		if m.lapLabeling {
+			return m.handleLapLabelKeys(msg)
+		}
+		if m.activeTab == 3 && m.boardMode && !m.confirmDelete && m.handleBoardKey(msg.String()) {
+			return m, nil
+		}
+		if m.activeTab == 7 && m.handleStopwatchKey(msg.String()) {
+			return m, nil
+		}
 
 		switch msg.String() {
 		case "ctrl+c", "q":
@@ -858,28 +1523,34 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.activeTab = 4
 		case "5":
 			m.activeTab = 5
+		case "6":
+			m.activeTab = 6
+		case "7":
+			m.activeTab = 7
 		case "left":
 			if m.activeTab > 1 {
 				m.activeTab--
 			} else if m.activeTab == 1 {
-				m.activeTab = 5
+				m.activeTab = 7
 			}
 		case "right":
-			if m.activeTab < 5 {
+			if m.activeTab < 7 {
 				m.activeTab++
-			} else if m.activeTab == 5 {
+			} else if m.activeTab == 7 {
 				m.activeTab = 1
 			}
 		case "up", "k":
-			if m.activeTab > 1 && m.activeTab < 6 {
+			if m.activeTab > 1 && m.activeTab < 7 {
 				m.tables[m.activeTab-2], _ = m.tables[m.activeTab-2].Update(msg)
 			}
 		case "down", "j":
-			if m.activeTab > 1 && m.activeTab < 6 {
+			if m.activeTab > 1 && m.activeTab < 7 {
 				m.tables[m.activeTab-2], _ = m.tables[m.activeTab-2].Update(msg)
 			}
 		case "e":
-			if m.activeTab > 1 && m.activeTab < 6 {
+			if m.activeTab > 1 && m.activeTab < 7 && len(m.marked) > 0 {
+				m.startBulkEdit()
+			} else if m.activeTab > 1 && m.activeTab < 7 {
 				m.startEditing()
 			}
 		case "n":
@@ -889,41 +1560,179 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.statusMsg = "Delete cancelled"
 				m.statusColor = "86"
 				m.statusExpiry = time.Now().Add(2 * time.Second)
-			} else if m.activeTab > 1 && m.activeTab < 6 {
+			} else if m.activeTab > 1 && m.activeTab < 7 {
 				m.addNew()
 			}
 		case "a":
-			if m.activeTab > 1 && m.activeTab < 6 {
+			if m.activeTab > 1 && m.activeTab < 7 {
 				m.addNew()
 			}
 		case "d", "delete":
-			if m.activeTab > 1 && m.activeTab < 6 && !m.confirmDelete {
+			if m.activeTab > 1 && m.activeTab < 7 && !m.confirmDelete && len(m.marked) > 0 {
+				m.confirmDeleteMarked()
+			} else if m.activeTab > 1 && m.activeTab < 7 && !m.confirmDelete {
 				m.confirmDeleteSelected()
 			}
 		case "y":
-			if m.confirmDelete {
+			if m.confirmDelete && len(m.marked) > 0 {
+				m.deleteMarked()
+				m.confirmDelete = false
+				m.deleteTarget = ""
+			} else if m.confirmDelete {
 				m.deleteSelected()
 				m.confirmDelete = false
 				m.deleteTarget = ""
 			}
 		case "s":
-			if m.activeTab == 4 {
+			if m.activeTab == 4 && len(m.marked) > 0 {
+				m.bulkReminderAction("start")
+			} else if m.activeTab == 4 {
 				m.toggleReminderStatus("start")
 			}
 		case "p":
-			if m.activeTab == 4 {
+			if m.activeTab == 4 && len(m.marked) > 0 {
+				m.bulkReminderAction("pause")
+			} else if m.activeTab == 4 {
 				m.toggleReminderStatus("pause")
 			}
 		case "r":
 			if m.activeTab == 4 {
 				m.toggleReminderStatus("reset")
 			}
+		case "i":
+			if m.activeTab == 5 {
+				m.startHistoryImport()
+			}
+		case "#":
+			m.startTagFilter()
+		case "esc":
+			if len(m.marked) > 0 {
+				m.clearMarks()
+			} else if m.tagFilter != "" {
+				m.setTagFilter("")
+				m.statusMsg = "Tag filter cleared"
+				m.statusColor = "86"
+				m.statusExpiry = time.Now().Add(2 * time.Second)
+			}
+		case "u":
+			m.undo()
+		case "ctrl+r":
+			m.redo()
+		case "T":
+			m.startTrash()
+		case "@":
+			m.startActivity()
+		case "t":
+			if m.activeTab == 2 || m.activeTab == 3 {
+				m.toggleTimer()
+			}
+		case "w":
+			m.reportOpen = true
+		case "P":
+			m.openPomodoro()
+		case "Z":
+			m.toggleDND()
+		case "v":
+			if m.activeTab > 1 && m.activeTab < 7 {
+				m.toggleMark()
+				m.tables[m.activeTab-2].MoveDown(1)
+			}
+		case "V":
+			if m.activeTab > 1 && m.activeTab < 7 {
+				m.markAll()
+			}
+		case "x":
+			if len(m.marked) > 0 {
+				m.markDone()
+			}
 		case " ", "enter":
+			// While rows are marked, space marks more rows
+			if msg.String() == " " && len(m.marked) > 0 && m.activeTab > 1 && m.activeTab < 7 {
+				m.toggleMark()
+				m.tables[m.activeTab-2].MoveDown(1)
+				break
+			}
 			// Toggle completion for dailies
 			if m.activeTab == 2 {
 				m.toggleCompletion()
 			}
+			if m.activeTab == 3 {
+				m.toggleTodo()
+			}
+		case "o":
+			if m.activeTab == 3 {
+				m.toggleExpanded()
+			}
+		case "c":
+			if m.activeTab == 2 || m.activeTab == 3 {
+				m.toggleGroup()
+			}
+		case "A":
+			if m.activeTab == 6 {
+				m.toggleProjectArchived()
+			} else if m.activeTab == 1 || m.activeTab == 4 {
+				m.acknowledgeSelected()
+			}
+		case "H":
+			if m.activeTab == 6 {
+				m.showArchived = !m.showArchived
+				m.tables[4].SetRows(m.projectRows())
+				m.statusMsg = "Hiding archived projects"
+				if m.showArchived {
+					m.statusMsg = "Showing archived projects"
+				}
+				m.statusColor = "86"
+				m.statusExpiry = time.Now().Add(2 * time.Second)
+			}
+		case "b":
+			if m.activeTab == 3 {
+				m.boardMode = !m.boardMode
+				m.tables[1].SetRows(m.rollingRows())
+				if m.boardMode {
+					m.syncBoardCursor()
+				}
+			}
+		case "R":
+			if m.activeTab == 3 {
+				m.readyOnly = !m.readyOnly
+				m.tables[1].SetRows(m.rollingRows())
+				m.tables[1].GotoTop()
+				m.statusMsg = "Showing all todos"
+				if m.readyOnly {
+					m.statusMsg = "Showing todos that are ready now"
+				}
+				m.statusColor = "86"
+				m.statusExpiry = time.Now().Add(2 * time.Second)
+			}
+		case "S":
+			if m.activeTab > 1 && m.activeTab < 7 {
+				m.startSortMenu()
+			}
+		case "J", "shift+down":
+			if m.activeTab > 1 && m.activeTab < 7 {
+				m.moveSelected(1)
+			}
+		case "K", "shift+up":
+			if m.activeTab > 1 && m.activeTab < 7 {
+				m.moveSelected(-1)
+			}
+		case "+":
+			if m.activeTab == 3 {
+				if row := m.selectedIndex(1); row != -1 {
+					m.startSubtaskEdit(row, -1)
+				}
+			}
+
+		}
 
+		// Marks only apply to the tab they were made on
+		if len(m.marked) > 0 && m.markedTab != m.activeTab {
+			m.clearMarks()
+		}
+
+		// Keep the selected card valid after rows were added or removed
+		if m.activeTab == 3 && m.boardMode {
+			m.syncBoardCursor()
 		}
 	}
 
@@ -934,10 +1743,13 @@ func (m model) handleEditingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
 		m.editing = false
+		m.bulkEdit = false
 		m.inputs = nil
 		return m, showStatus("❌ Edit cancelled", "196")
 	case "enter":
-		m.saveEdit()
+		if err := m.saveEdit(); err != nil {
+			return m, showStatus("❌ "+err.Error(), "196")
+		}
 		m.editing = false
 		m.inputs = nil
 		return m, showStatus("✅ Changes saved", "82")
@@ -968,16 +1780,30 @@ func (m model) handleEditingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *model) startEditing() {
+	row := m.selectedIndex(m.activeTab - 2)
+	if row == -1 {
+		return
+	}
+
+	if m.activeTab == 3 {
+		if sub := m.selectedSubtask(); sub != -1 {
+			m.startSubtaskEdit(row, sub)
+			return
+		}
+	}
+
 	m.editing = true
 	m.editingTab = m.activeTab
-	m.editingRow = m.tables[m.activeTab-2].Cursor()
+	m.editingRow = row
 	m.editingField = 0
+	m.editingSubtask = false
+	m.bulkEdit = false
 
 	switch m.editingTab {
 	case 2: // Dailies
 		if m.editingRow < len(m.data.Dailies) {
 			daily := m.data.Dailies[m.editingRow]
-			m.inputs = make([]textinput.Model, 4)
+			m.inputs = make([]textinput.Model, 5)
 			m.inputs[0] = textinput.New()
 			m.inputs[0].SetValue(daily.Task)
 			m.inputs[0].Focus()
@@ -987,11 +1813,13 @@ func (m *model) startEditing() {
 			m.inputs[2].SetValue(daily.Category)
 			m.inputs[3] = textinput.New()
 			m.inputs[3].SetValue(daily.Deadline)
+			m.inputs[4] = textinput.New()
+			m.inputs[4].SetValue(formatTags(daily.Tags))
 		}
 	case 3: // Rolling Todos
 		if m.editingRow < len(m.data.RollingTodos) {
 			todo := m.data.RollingTodos[m.editingRow]
-			m.inputs = make([]textinput.Model, 4)
+			m.inputs = make([]textinput.Model, 7)
 			m.inputs[0] = textinput.New()
 			m.inputs[0].SetValue(todo.Task)
 			m.inputs[0].Focus()
@@ -1001,11 +1829,17 @@ func (m *model) startEditing() {
 			m.inputs[2].SetValue(todo.Category)
 			m.inputs[3] = textinput.New()
 			m.inputs[3].SetValue(todo.Deadline)
+			m.inputs[4] = textinput.New()
+			m.inputs[4].SetValue(formatTags(todo.Tags))
+			m.inputs[5] = textinput.New()
+			m.inputs[5].SetValue(formatDependencies(todo.BlockedBy))
+			m.inputs[6] = textinput.New()
+			m.inputs[6].SetValue(projectName(m.data.Projects, todo.ProjectID))
 		}
 	case 4: // Reminders
 		if m.editingRow < len(m.data.Reminders) {
 			reminder := m.data.Reminders[m.editingRow]
-			m.inputs = make([]textinput.Model, 3)
+			m.inputs = make([]textinput.Model, 9)
 			m.inputs[0] = textinput.New()
 			m.inputs[0].SetValue(reminder.Reminder)
 			m.inputs[0].Focus()
@@ -1013,11 +1847,32 @@ func (m *model) startEditing() {
 			m.inputs[1].SetValue(reminder.Note)
 			m.inputs[2] = textinput.New()
 			m.inputs[2].SetValue(reminder.AlarmOrCountdown)
+			m.inputs[3] = textinput.New()
+			m.inputs[3].SetValue(formatTags(reminder.Tags))
+			m.inputs[4] = textinput.New()
+			m.inputs[4].SetValue(strings.Join(reminder.Notify, ", "))
+			m.inputs[4].Placeholder = strings.Join(m.data.Notify.defaults(), ", ")
+			m.inputs[5] = textinput.New()
+			m.inputs[5].SetValue(reminder.Sound)
+			m.inputs[5].Placeholder = "default"
+			m.inputs[6] = textinput.New()
+			if reminder.NagMinutes > 0 {
+				m.inputs[6].SetValue(strconv.Itoa(reminder.NagMinutes))
+			}
+			m.inputs[6].Placeholder = "once"
+			m.inputs[7] = textinput.New()
+			m.inputs[7].SetValue(formatLeads(reminder.LeadMinutes))
+			m.inputs[7].Placeholder = "e.g. 10m, 1m"
+			m.inputs[8] = textinput.New()
+			if reminder.BreakDND {
+				m.inputs[8].SetValue("yes")
+			}
+			m.inputs[8].Placeholder = "no"
 		}
 	case 5: // Glossary
 		if m.editingRow < len(m.data.Glossary) {
 			item := m.data.Glossary[m.editingRow]
-			m.inputs = make([]textinput.Model, 5)
+			m.inputs = make([]textinput.Model, 6)
 			m.inputs[0] = textinput.New()
 			m.inputs[0].SetValue(item.Lang)
 			m.inputs[0].Focus()
@@ -1029,6 +1884,24 @@ func (m *model) startEditing() {
 			m.inputs[3].SetValue(item.Example)
 			m.inputs[4] = textinput.New()
 			m.inputs[4].SetValue(item.Meaning)
+			m.inputs[5] = textinput.New()
+			m.inputs[5].SetValue(formatTags(item.Tags))
+		}
+	case 6: // Projects
+		if m.editingRow < len(m.data.Projects) {
+			project := m.data.Projects[m.editingRow]
+			m.inputs = make([]textinput.Model, 5)
+			m.inputs[0] = textinput.New()
+			m.inputs[0].SetValue(project.Name)
+			m.inputs[0].Focus()
+			m.inputs[1] = textinput.New()
+			m.inputs[1].SetValue(project.Description)
+			m.inputs[2] = textinput.New()
+			m.inputs[2].SetValue(project.Color)
+			m.inputs[3] = textinput.New()
+			m.inputs[3].SetValue(project.Status)
+			m.inputs[4] = textinput.New()
+			m.inputs[4].SetValue(project.Due)
 		}
 	}
 }
@@ -1038,27 +1911,40 @@ func (m *model) addNew() {
 	m.editingTab = m.activeTab
 	m.editingRow = -1 // Indicates new item
 	m.editingField = 0
+	m.editingSubtask = false
+	m.bulkEdit = false
 
 	switch m.activeTab {
 	case 2: // Dailies
-		m.inputs = make([]textinput.Model, 4)
+		m.inputs = make([]textinput.Model, 5)
 		for i := range m.inputs {
 			m.inputs[i] = textinput.New()
 		}
 		m.inputs[0].Focus()
 	case 3: // Rolling Todos
-		m.inputs = make([]textinput.Model, 4)
+		m.inputs = make([]textinput.Model, 7)
 		for i := range m.inputs {
 			m.inputs[i] = textinput.New()
 		}
 		m.inputs[0].Focus()
 	case 4: // Reminders
-		m.inputs = make([]textinput.Model, 3)
+		m.inputs = make([]textinput.Model, 9)
 		for i := range m.inputs {
 			m.inputs[i] = textinput.New()
 		}
+		m.inputs[4].Placeholder = strings.Join(m.data.Notify.defaults(), ", ")
+		m.inputs[5].Placeholder = "default"
+		m.inputs[6].Placeholder = "once"
+		m.inputs[7].Placeholder = "e.g. 10m, 1m"
+		m.inputs[8].Placeholder = "no"
 		m.inputs[0].Focus()
 	case 5: // Glossary
+		m.inputs = make([]textinput.Model, 6)
+		for i := range m.inputs {
+			m.inputs[i] = textinput.New()
+		}
+		m.inputs[0].Focus()
+	case 6: // Projects
 		m.inputs = make([]textinput.Model, 5)
 		for i := range m.inputs {
 			m.inputs[i] = textinput.New()
@@ -1067,19 +1953,25 @@ func (m *model) addNew() {
 	}
 }
 
-func (m *model) saveEdit() {
+func (m *model) saveEdit() error {
+	if m.bulkEdit {
+		return m.saveBulkEdit()
+	}
+	before := snapshot(m.data)
+
 	switch m.editingTab {
 	case 2: // Dailies
 		if m.editingRow == -1 {
 			// New item
 			newDaily := Daily{
-				ID:            len(m.data.Dailies) + 1,
+				ID:            nextDailyID(m.data.Dailies),
 				Task:          normalizeText(m.inputs[0].Value()),
 				Priority:      normalizePriority(m.inputs[1].Value()),
 				Category:      normalizeText(m.inputs[2].Value()),
 				Deadline:      m.inputs[3].Value(),
 				Status:        "INCOMPLETE",
 				LastCompleted: time.Time{},
+				Tags:          parseTags(m.inputs[4].Value()),
 			}
 			m.data.Dailies = append(m.data.Dailies, newDaily)
 		} else {
@@ -1088,16 +1980,44 @@ func (m *model) saveEdit() {
 			m.data.Dailies[m.editingRow].Priority = normalizePriority(m.inputs[1].Value())
 			m.data.Dailies[m.editingRow].Category = normalizeText(m.inputs[2].Value())
 			m.data.Dailies[m.editingRow].Deadline = m.inputs[3].Value()
+			m.data.Dailies[m.editingRow].Tags = parseTags(m.inputs[4].Value())
 		}
 		m.tables[0].SetRows(m.dailyRows())
 	case 3: // Rolling Todos
+		if m.editingSubtask {
+			if !m.saveSubtaskEdit() {
+				// Nothing to log or undo
+				return nil
+			}
+			break
+		}
+
+		id := nextTodoID(m.data.RollingTodos)
+		if m.editingRow != -1 {
+			id = m.data.RollingTodos[m.editingRow].ID
+		}
+		deps, err := parseDependencies(m.inputs[5].Value())
+		if err != nil {
+			return err
+		}
+		if err := validateDependencies(m.data.RollingTodos, id, deps); err != nil {
+			return err
+		}
+		projectID, err := findProjectID(m.data.Projects, m.inputs[6].Value())
+		if err != nil {
+			return err
+		}
+
 		if m.editingRow == -1 {
 			newTodo := RollingTodo{
-				ID:       len(m.data.RollingTodos) + 1,
-				Task:     normalizeText(m.inputs[0].Value()),
-				Priority: normalizePriority(m.inputs[1].Value()),
-				Category: normalizeText(m.inputs[2].Value()),
-				Deadline: m.inputs[3].Value(),
+				ID:        id,
+				Task:      normalizeText(m.inputs[0].Value()),
+				Priority:  normalizePriority(m.inputs[1].Value()),
+				Category:  normalizeText(m.inputs[2].Value()),
+				Deadline:  m.inputs[3].Value(),
+				Tags:      parseTags(m.inputs[4].Value()),
+				BlockedBy: deps,
+				ProjectID: projectID,
 			}
 			m.data.RollingTodos = append(m.data.RollingTodos, newTodo)
 		} else {
@@ -1105,22 +2025,57 @@ func (m *model) saveEdit() {
 			m.data.RollingTodos[m.editingRow].Priority = normalizePriority(m.inputs[1].Value())
 			m.data.RollingTodos[m.editingRow].Category = normalizeText(m.inputs[2].Value())
 			m.data.RollingTodos[m.editingRow].Deadline = m.inputs[3].Value()
+			m.data.RollingTodos[m.editingRow].Tags = parseTags(m.inputs[4].Value())
+			m.data.RollingTodos[m.editingRow].BlockedBy = deps
+			m.data.RollingTodos[m.editingRow].ProjectID = projectID
 		}
 		m.tables[1].SetRows(m.rollingRows())
+		m.tables[4].SetRows(m.projectRows())
 	case 4: // Reminders
+		notify, err := parseNotifiers(m.inputs[4].Value())
+		if err != nil {
+			return err
+		}
+		sound := strings.TrimSpace(m.inputs[5].Value())
+		if err := validateSound(sound); err != nil {
+			return err
+		}
+		nagMinutes := 0
+		if value := strings.TrimSpace(m.inputs[6].Value()); value != "" {
+			if nagMinutes, err = strconv.Atoi(value); err != nil || nagMinutes < 0 {
+				return fmt.Errorf("repeat interval must be a number of minutes")
+			}
+		}
+		leads, err := parseLeads(m.inputs[7].Value())
+		if err != nil {
+			return err
+		}
+		breakDND := false
+		switch strings.ToLower(strings.TrimSpace(m.inputs[8].Value())) {
+		case "y", "yes":
+			breakDND = true
+		case "", "n", "no":
+		default:
+			return fmt.Errorf("break through Do Not Disturb must be yes or no")
+		}
 		if m.editingRow == -1 {
 			newReminder := Reminder{
-				ID:               len(m.data.Reminders) + 1,
+				ID:               nextReminderID(m.data.Reminders),
 				Reminder:         normalizeText(m.inputs[0].Value()),
 				Note:             normalizeText(m.inputs[1].Value()),
 				AlarmOrCountdown: m.inputs[2].Value(),
 				CreatedAt:        time.Now(),
 				Notified:         false,
+				Tags:             parseTags(m.inputs[3].Value()),
+				Notify:           notify,
+				Sound:            sound,
+				NagMinutes:       nagMinutes,
+				LeadMinutes:      leads,
+				BreakDND:         breakDND,
 			}
 			// Parse countdown or alarm
-			if targetTime, isCountdown := parseCountdown(m.inputs[2].Value()); isCountdown {
-				newReminder.TargetTime = targetTime
-				newReminder.IsCountdown = true
+			if d, isCountdown := countdownDuration(m.inputs[2].Value()); isCountdown {
+				newReminder.startCountdown(d, time.Now())
 				newReminder.Status = "active"
 			} else if targetTime, isAlarm := parseAlarmTime(m.inputs[2].Value()); isAlarm {
 				newReminder.TargetTime = targetTime
@@ -1132,10 +2087,19 @@ func (m *model) saveEdit() {
 			m.data.Reminders[m.editingRow].Reminder = normalizeText(m.inputs[0].Value())
 			m.data.Reminders[m.editingRow].Note = normalizeText(m.inputs[1].Value())
 			m.data.Reminders[m.editingRow].AlarmOrCountdown = m.inputs[2].Value()
-			// Re-parse countdown or alarm when editing
-			if targetTime, isCountdown := parseCountdown(m.inputs[2].Value()); isCountdown {
-				m.data.Reminders[m.editingRow].TargetTime = targetTime
-				m.data.Reminders[m.editingRow].IsCountdown = true
+			m.data.Reminders[m.editingRow].Tags = parseTags(m.inputs[3].Value())
+			m.data.Reminders[m.editingRow].Notify = notify
+			m.data.Reminders[m.editingRow].Sound = sound
+			m.data.Reminders[m.editingRow].NagMinutes = nagMinutes
+			m.data.Reminders[m.editingRow].LeadMinutes = leads
+			m.data.Reminders[m.editingRow].BreakDND = breakDND
+			// Re-parse countdown or alarm when editing, unless nothing was
+			// changed, which would restart the countdown
+			if bytes.Equal(snapshot(m.data), before) {
+				break
+			}
+			if d, isCountdown := countdownDuration(m.inputs[2].Value()); isCountdown {
+				m.data.Reminders[m.editingRow].startCountdown(d, time.Now())
 				m.data.Reminders[m.editingRow].Notified = false
 				m.data.Reminders[m.editingRow].Status = "active"
 			} else if targetTime, isAlarm := parseAlarmTime(m.inputs[2].Value()); isAlarm {
@@ -1149,12 +2113,13 @@ func (m *model) saveEdit() {
 	case 5: // Glossary
 		if m.editingRow == -1 {
 			newItem := GlossaryItem{
-				ID:      len(m.data.Glossary) + 1,
+				ID:      nextGlossaryID(m.data.Glossary),
 				Lang:    normalizeText(m.inputs[0].Value()),
 				Command: normalizeText(m.inputs[1].Value()),
 				Usage:   normalizeText(m.inputs[2].Value()),
 				Example: normalizeText(m.inputs[3].Value()),
 				Meaning: normalizeText(m.inputs[4].Value()),
+				Tags:    parseTags(m.inputs[5].Value()),
 			}
 			m.data.Glossary = append(m.data.Glossary, newItem)
 		} else {
@@ -1163,15 +2128,74 @@ func (m *model) saveEdit() {
 			m.data.Glossary[m.editingRow].Usage = normalizeText(m.inputs[2].Value())
 			m.data.Glossary[m.editingRow].Example = normalizeText(m.inputs[3].Value())
 			m.data.Glossary[m.editingRow].Meaning = normalizeText(m.inputs[4].Value())
+			m.data.Glossary[m.editingRow].Tags = parseTags(m.inputs[5].Value())
 		}
 		m.tables[3].SetRows(m.glossaryRows())
+	case 6: // Projects
+		name := normalizeText(m.inputs[0].Value())
+		if name == "" {
+			return fmt.Errorf("project name is required")
+		}
+		for i, project := range m.data.Projects {
+			if i != m.editingRow && strings.EqualFold(project.Name, name) {
+				return fmt.Errorf("a project named %q already exists", project.Name)
+			}
+		}
+		if m.editingRow == -1 {
+			newProject := Project{
+				ID:          nextProjectID(m.data.Projects),
+				Name:        name,
+				Description: normalizeText(m.inputs[1].Value()),
+				Color:       normalizeText(m.inputs[2].Value()),
+				Status:      normalizeProjectStatus(m.inputs[3].Value()),
+				Due:         m.inputs[4].Value(),
+			}
+			m.data.Projects = append(m.data.Projects, newProject)
+		} else {
+			m.data.Projects[m.editingRow].Name = name
+			m.data.Projects[m.editingRow].Description = normalizeText(m.inputs[1].Value())
+			m.data.Projects[m.editingRow].Color = normalizeText(m.inputs[2].Value())
+			m.data.Projects[m.editingRow].Status = normalizeProjectStatus(m.inputs[3].Value())
+			m.data.Projects[m.editingRow].Due = m.inputs[4].Value()
+		}
+		m.tables[4].SetRows(m.projectRows())
+		m.tables[1].SetRows(m.rollingRows())
+	}
+
+	if m.editingRow != -1 && bytes.Equal(snapshot(m.data), before) {
+		// Nothing to log or undo
+		return nil
 	}
 
-	saveData(m.data)
+	switch {
+	case m.editingSubtask:
+		todo := m.data.RollingTodos[m.editingRow]
+		event, sub := "edited", m.editingSubtaskIdx
+		if sub == -1 {
+			event, sub = "created", len(todo.Subtasks)-1
+		}
+		if sub >= 0 && sub < len(todo.Subtasks) {
+			m.logActivity(event, "subtask", todo.ID, todo.Task+" › "+todo.Subtasks[sub].Task)
+		}
+	case m.editingRow == -1:
+		m.logItem("created", m.editingTab, m.itemCount(m.editingTab)-1)
+	default:
+		m.logItem("edited", m.editingTab, m.editingRow)
+	}
+
+	if m.editingRow == -1 {
+		m.save("add")
+	} else {
+		m.save("edit")
+	}
+	return nil
 }
 
 func (m *model) confirmDeleteSelected() {
-	cursor := m.tables[m.activeTab-2].Cursor()
+	cursor := m.selectedIndex(m.activeTab - 2)
+	if cursor == -1 {
+		return
+	}
 	var itemName string
 
 	switch m.activeTab {
@@ -1180,7 +2204,9 @@ func (m *model) confirmDeleteSelected() {
 			itemName = m.data.Dailies[cursor].Task
 		}
 	case 3: // Rolling Todos
-		if cursor < len(m.data.RollingTodos) {
+		if sub := m.selectedSubtask(); sub != -1 {
+			itemName = m.data.RollingTodos[cursor].Subtasks[sub].Task
+		} else if cursor < len(m.data.RollingTodos) {
 			itemName = m.data.RollingTodos[cursor].Task
 		}
 	case 4: // Reminders
@@ -1191,6 +2217,10 @@ func (m *model) confirmDeleteSelected() {
 		if cursor < len(m.data.Glossary) {
 			itemName = m.data.Glossary[cursor].Command
 		}
+	case 6: // Projects
+		if cursor < len(m.data.Projects) {
+			itemName = m.data.Projects[cursor].Name
+		}
 	}
 
 	if itemName != "" {
@@ -1200,12 +2230,17 @@ func (m *model) confirmDeleteSelected() {
 }
 
 func (m *model) deleteSelected() {
-	cursor := m.tables[m.activeTab-2].Cursor()
+	cursor := m.selectedIndex(m.activeTab - 2)
+	if cursor == -1 {
+		return
+	}
 
 	switch m.activeTab {
 	case 2: // Dailies
 		if cursor < len(m.data.Dailies) {
 			taskName := m.data.Dailies[cursor].Task
+			m.logItem("deleted", m.activeTab, cursor)
+			m.trashItem(m.activeTab, cursor)
 			m.data.Dailies = append(m.data.Dailies[:cursor], m.data.Dailies[cursor+1:]...)
 			m.tables[0].SetRows(m.dailyRows())
 			m.statusMsg = fmt.Sprintf("🗑️ Deleted: %s", taskName)
@@ -1213,10 +2248,17 @@ func (m *model) deleteSelected() {
 			m.statusExpiry = time.Now().Add(3 * time.Second)
 		}
 	case 3: // Rolling Todos
-		if cursor < len(m.data.RollingTodos) {
+		if sub := m.selectedSubtask(); sub != -1 {
+			m.deleteSubtask(cursor, sub)
+		} else if cursor < len(m.data.RollingTodos) {
 			taskName := m.data.RollingTodos[cursor].Task
+			removedID := m.data.RollingTodos[cursor].ID
+			m.logItem("deleted", m.activeTab, cursor)
+			m.trashItem(m.activeTab, cursor)
 			m.data.RollingTodos = append(m.data.RollingTodos[:cursor], m.data.RollingTodos[cursor+1:]...)
+			removeDependency(m.data.RollingTodos, removedID)
 			m.tables[1].SetRows(m.rollingRows())
+			m.tables[4].SetRows(m.projectRows())
 			m.statusMsg = fmt.Sprintf("🗑️ Deleted: %s", taskName)
 			m.statusColor = "196"
 			m.statusExpiry = time.Now().Add(3 * time.Second)
@@ -1224,6 +2266,8 @@ func (m *model) deleteSelected() {
 	case 4: // Reminders
 		if cursor < len(m.data.Reminders) {
 			reminderName := m.data.Reminders[cursor].Reminder
+			m.logItem("deleted", m.activeTab, cursor)
+			m.trashItem(m.activeTab, cursor)
 			m.data.Reminders = append(m.data.Reminders[:cursor], m.data.Reminders[cursor+1:]...)
 			m.tables[2].SetRows(m.reminderRows())
 			m.statusMsg = fmt.Sprintf("🗑️ Deleted: %s", reminderName)
@@ -1233,28 +2277,89 @@ func (m *model) deleteSelected() {
 	case 5: // Glossary
 		if cursor < len(m.data.Glossary) {
 			itemName := m.data.Glossary[cursor].Command
+			m.logItem("deleted", m.activeTab, cursor)
+			m.trashItem(m.activeTab, cursor)
 			m.data.Glossary = append(m.data.Glossary[:cursor], m.data.Glossary[cursor+1:]...)
 			m.tables[3].SetRows(m.glossaryRows())
 			m.statusMsg = fmt.Sprintf("🗑️ Deleted: %s", itemName)
 			m.statusColor = "196"
 			m.statusExpiry = time.Now().Add(3 * time.Second)
 		}
+	case 6: // Projects
+		if cursor < len(m.data.Projects) {
+			projectName := m.data.Projects[cursor].Name
+			removedID := m.data.Projects[cursor].ID
+			m.logItem("deleted", m.activeTab, cursor)
+			m.trashItem(m.activeTab, cursor)
+			m.data.Projects = append(m.data.Projects[:cursor], m.data.Projects[cursor+1:]...)
+			// Todos of a deleted project stay, just without a project
+			for i := range m.data.RollingTodos {
+				if m.data.RollingTodos[i].ProjectID == removedID {
+					m.data.RollingTodos[i].ProjectID = 0
+				}
+			}
+			m.tables[4].SetRows(m.projectRows())
+			m.tables[1].SetRows(m.rollingRows())
+			m.statusMsg = fmt.Sprintf("🗑️ Deleted: %s", projectName)
+			m.statusColor = "196"
+			m.statusExpiry = time.Now().Add(3 * time.Second)
+		}
 	}
 
-	saveData(m.data)
+	m.save("delete")
 }
 
 func (m model) View() string {
+	if m.bell {
+		return "\a" + m.view()
+	}
+	return m.view()
+}
+
+func (m model) view() string {
+	if len(m.alerts) > 0 {
+		return m.alertView()
+	}
+	if m.missedOpen {
+		return m.missedView()
+	}
 	if m.editing {
 		return m.editView()
 	}
+	if m.importing {
+		return m.importView()
+	}
+	if m.trashOpen {
+		return m.trashView()
+	}
+	if m.activityOpen {
+		return m.activityView()
+	}
+	if m.reportOpen {
+		return m.reportView()
+	}
+	if m.pomodoroOpen {
+		return m.pomodoroView()
+	}
 
 	// Header
 	header := headerStyle.Render("📋 lif - lucas is forgetful")
+	if timer := m.timerHeader(); timer != "" {
+		header += "  " + timer
+	}
+	if pomodoro := m.pomodoroHeader(); pomodoro != "" {
+		header += "  " + pomodoro
+	}
+	if stopwatch := m.stopwatchHeader(); stopwatch != "" {
+		header += "  " + stopwatch
+	}
+	if dnd := m.dndHeader(); dnd != "" {
+		header += "  " + dnd
+	}
 
 	// Tab headers
 	tabs := []string{}
-	tabNames := []string{"[1] Home", "[2] Dailies", "[3] Rolling", "[4] Reminders", "[5] Glossary"}
+	tabNames := []string{"[1] Home", "[2] Dailies", "[3] Rolling", "[4] Reminders", "[5] Glossary", "[6] Projects", "[7] Stopwatch"}
 
 	for i, name := range tabNames {
 		if i+1 == m.activeTab {
@@ -1278,9 +2383,25 @@ func (m model) View() string {
 			}
 		}
 		summary := fmt.Sprintf("\nDaily Tasks: %d total, %d completed\n", totalDailies, completedDailies)
-		summary += fmt.Sprintf("Rolling Todos: %d items\n", len(m.data.RollingTodos))
+		doneTodos := 0
+		for _, todo := range m.data.RollingTodos {
+			if todo.Done {
+				doneTodos++
+			}
+		}
+		summary += fmt.Sprintf("Rolling Todos: %d items, %d done\n", len(m.data.RollingTodos), doneTodos)
 		summary += fmt.Sprintf("Reminders: %d active\n", len(m.data.Reminders))
 		summary += fmt.Sprintf("Glossary: %d entries\n", len(m.data.Glossary))
+		activeProjects := 0
+		for _, project := range m.data.Projects {
+			if !project.Archived && project.Status == "active" {
+				activeProjects++
+			}
+		}
+		summary += fmt.Sprintf("Projects: %d active\n", activeProjects)
+		if count := m.data.Pomodoro.today(time.Now()); count > 0 {
+			summary += fmt.Sprintf("Pomodoros today: %d\n", count)
+		}
 
 		if len(m.data.RollingTodos) > 0 {
 			summary += "\n" + priorityHighStyle.Render("Check your Rolling Todo List!")
@@ -1297,6 +2418,10 @@ func (m model) View() string {
 		if len(expiredReminders) > 0 {
 			summary += "\n" + statusOverdueStyle.Render("\nExpired Reminders:") + "\n"
 			for _, reminder := range expiredReminders {
+				if reminder.nagging() {
+					summary += fmt.Sprintf("  🔁  - %s (A to acknowledge)\n", reminder.Reminder)
+					continue
+				}
 				summary += fmt.Sprintf("  ⚠️  - %s\n", reminder.Reminder)
 			}
 		}
@@ -1346,10 +2471,15 @@ func (m model) View() string {
 					// Active reminder - show live countdown
 					remaining := time.Until(reminder.TargetTime)
 					if remaining > 0 {
+						when := reminder.TargetTime.Format("15:04")
 						if reminder.IsCountdown {
-							summary += fmt.Sprintf("  %s %s: %s\n", statusIcon, reminder.Reminder, formatDuration(remaining))
+							when = formatDuration(remaining)
+						}
+						// Reminders about to fire stand out once their first alert is due
+						if reminder.inLeadWindow(time.Now()) {
+							summary += priorityMedStyle.Render(fmt.Sprintf("  ⏰ %s: %s (in %s)", reminder.Reminder, when, formatDuration(remaining))) + "\n"
 						} else {
-							summary += fmt.Sprintf("  %s %s: %s\n", statusIcon, reminder.Reminder, reminder.TargetTime.Format("15:04"))
+							summary += fmt.Sprintf("  %s %s: %s\n", statusIcon, reminder.Reminder, when)
 						}
 					} else {
 						summary += fmt.Sprintf("  ⚠️ %s: EXPIRED\n", reminder.Reminder)
@@ -1358,30 +2488,113 @@ func (m model) View() string {
 			}
 		}
 
+		if cloud := tagCloud(m.data, m.width); cloud != "" {
+			summary += "\n\n" + headerStyle.Render("Tags:") + "\n" + cloud
+		}
+
 		content = summary
+	} else if m.activeTab == 5 {
+		// Glossary table with the selected entry shown in full
+		content = m.tables[3].View()
+		if detail := m.glossaryDetail(); detail != "" {
+			if m.glossaryDetailSide() {
+				content = lipgloss.JoinHorizontal(lipgloss.Top, content, "  ", detail)
+			} else {
+				content = lipgloss.JoinVertical(lipgloss.Left, content, detail)
+			}
+		}
+	} else if m.activeTab == 3 && m.boardMode {
+		content = m.boardView()
+	} else if m.activeTab == 7 {
+		content = m.stopwatchView()
 	} else {
 		// Table content
 		content = m.tables[m.activeTab-2].View()
+		if tags := m.selectedTags(); len(tags) > 0 {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, renderTags(tags))
+		}
+		if tracked := m.selectedTracked(); tracked != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, tracked)
+		}
+	}
+
+	if m.tagFilter != "" && m.activeTab > 1 && m.activeTab < 7 {
+		filterLine := bulletStyle.Render("Filter: ") + renderTags([]string{m.tagFilter}) + bulletStyle.Render(" (esc to clear)")
+		content = lipgloss.JoinVertical(lipgloss.Left, filterLine, content)
+	}
+	if m.activeTab > 1 {
+		if sortLine := m.sortLine(); sortLine != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, sortLine, content)
+		}
 	}
 
 	// Enhanced footer with color coding
 	var commands []string
 	if m.activeTab == 1 {
-		commands = append(commands, keyStyle.Render("1-5")+": "+actionStyle.Render("navigate"))
+		commands = append(commands, keyStyle.Render("1-7")+": "+actionStyle.Render("navigate"))
+		if m.naggingCount() > 0 {
+			commands = append(commands, keyStyle.Render("A")+": "+actionStyle.Render("acknowledge all"))
+		}
+	} else if m.activeTab == 7 {
+		commands = append(commands, m.stopwatchCommands()...)
 	} else {
 		commands = append(commands, keyStyle.Render("↑↓")+": "+actionStyle.Render("navigate"))
 		commands = append(commands, keyStyle.Render("e")+": "+actionStyle.Render("edit"))
 		commands = append(commands, keyStyle.Render("n/a")+": "+actionStyle.Render("add"))
 		commands = append(commands, keyStyle.Render("d")+": "+actionStyle.Render("delete"))
+		commands = append(commands, keyStyle.Render("S")+": "+actionStyle.Render("sort"))
+		commands = append(commands, keyStyle.Render("v/V")+": "+actionStyle.Render("select"))
+		if len(m.marked) > 0 {
+			commands = append(commands, keyStyle.Render(fmt.Sprintf("%d selected", len(m.marked))))
+			if m.activeTab == 2 || m.activeTab == 3 {
+				commands = append(commands, keyStyle.Render("x")+": "+actionStyle.Render("mark done"))
+				commands = append(commands, keyStyle.Render("e")+": "+actionStyle.Render("bulk edit"))
+			}
+			commands = append(commands, keyStyle.Render("esc")+": "+actionStyle.Render("clear selection"))
+		}
+		commands = append(commands, keyStyle.Render("J/K")+": "+actionStyle.Render("move"))
+		if m.activeTab != 6 {
+			commands = append(commands, keyStyle.Render("#")+": "+actionStyle.Render("filter tag"))
+		}
+		if m.activeTab == 2 || m.activeTab == 3 {
+			commands = append(commands, keyStyle.Render("t")+": "+actionStyle.Render("start/stop timer"))
+		}
 		if m.activeTab == 2 {
 			commands = append(commands, keyStyle.Render("space/enter")+": "+actionStyle.Render("toggle done"))
+			commands = append(commands, keyStyle.Render("c")+": "+actionStyle.Render("collapse group"))
+		}
+		if m.activeTab == 3 {
+			commands = append(commands, keyStyle.Render("space/enter")+": "+actionStyle.Render("toggle done"))
+			commands = append(commands, keyStyle.Render("+")+": "+actionStyle.Render("add subtask"))
+			commands = append(commands, keyStyle.Render("o")+": "+actionStyle.Render("expand"))
+			commands = append(commands, keyStyle.Render("c")+": "+actionStyle.Render("collapse group"))
+			commands = append(commands, keyStyle.Render("R")+": "+actionStyle.Render("ready now"))
+			commands = append(commands, keyStyle.Render("b")+": "+actionStyle.Render("board"))
+			if m.boardMode {
+				commands = append(commands, keyStyle.Render("h/l")+": "+actionStyle.Render("column"))
+				commands = append(commands, keyStyle.Render("</>")+": "+actionStyle.Render("move card"))
+			}
+		}
+		if m.activeTab == 6 {
+			commands = append(commands, keyStyle.Render("A")+": "+actionStyle.Render("archive"))
+			commands = append(commands, keyStyle.Render("H")+": "+actionStyle.Render("show archived"))
+		}
+		if m.activeTab == 5 {
+			commands = append(commands, keyStyle.Render("i")+": "+actionStyle.Render("import history"))
 		}
 		if m.activeTab == 4 {
+			commands = append(commands, keyStyle.Render("A")+": "+actionStyle.Render("acknowledge"))
 			commands = append(commands, keyStyle.Render("s")+": "+actionStyle.Render("start/resume"))
 			commands = append(commands, keyStyle.Render("p")+": "+actionStyle.Render("pause"))
 			commands = append(commands, keyStyle.Render("r")+": "+actionStyle.Render("reset"))
 		}
 	}
+	commands = append(commands, keyStyle.Render("u/ctrl+r")+": "+actionStyle.Render("undo/redo"))
+	commands = append(commands, keyStyle.Render("T")+": "+actionStyle.Render("trash"))
+	commands = append(commands, keyStyle.Render("@")+": "+actionStyle.Render("activity"))
+	commands = append(commands, keyStyle.Render("w")+": "+actionStyle.Render("time report"))
+	commands = append(commands, keyStyle.Render("P")+": "+actionStyle.Render("pomodoro"))
+	commands = append(commands, keyStyle.Render("Z")+": "+actionStyle.Render("do not disturb"))
 	commands = append(commands, keyStyle.Render("q")+": "+actionStyle.Render("quit"))
 
 	commandRow := strings.Join(commands, bulletStyle.Render(" • "))
@@ -1392,6 +2605,13 @@ func (m model) View() string {
 		commandRow += "\n> " + statusStyle.Render(m.statusMsg)
 	}
 
+	if m.filtering {
+		commandRow += "\n> " + m.filterInput.View()
+	}
+	if m.sortMenu {
+		commandRow += "\n> " + m.sortMenuView()
+	}
+
 	// Delete confirmation message
 	if m.confirmDelete {
 		deleteStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
@@ -1414,13 +2634,24 @@ func (m model) editView() string {
 
 	switch m.editingTab {
 	case 2: // Dailies
-		labels = []string{"Task:", "Priority:", "Category:", "Deadline:", "Status:"}
+		labels = []string{"Task:", "Priority:", "Category:", "Deadline:", "Tags (#tag):"}
+		if m.bulkEdit {
+			labels = []string{"Priority:", "Category:"}
+		}
 	case 3: // Rolling Todos
-		labels = []string{"Task:", "Priority:", "Category:", "Deadline:"}
+		labels = []string{"Task:", "Priority:", "Category:", "Deadline:", "Tags (#tag):", "Blocked by (todo IDs):", "Project:"}
+		if m.editingSubtask {
+			labels = []string{"Subtask:"}
+		}
+		if m.bulkEdit {
+			labels = []string{"Priority:", "Category:", "Project (- for none):"}
+		}
 	case 4: // Reminders
-		labels = []string{"Reminder:", "Note:", "Alarm/Countdown:"}
+		labels = []string{"Reminder:", "Note:", "Alarm/Countdown:", "Tags (#tag):", "Notify via (desktop, bell, modal, command, webhook, email):", "Sound (name in ~/.config/lif/sounds, path or none):", "Repeat every (minutes, until acknowledged):", "Alert before (e.g. 10m, 1m):", "Break through Do Not Disturb (yes/no):"}
 	case 5: // Glossary
-		labels = []string{"Lang:", "Command:", "Usage:", "Example:", "Meaning:"}
+		labels = []string{"Lang:", "Command:", "Usage:", "Example:", "Meaning:", "Tags (#tag):"}
+	case 6: // Projects
+		labels = []string{"Name:", "Description:", "Color (0-255 or #hex):", "Status (active/on-hold/done):", "Due:"}
 	}
 
 	for i, input := range m.inputs {
@@ -1431,6 +2662,9 @@ func (m model) editView() string {
 	content := lipgloss.JoinVertical(lipgloss.Top, fields...)
 
 	header := headerStyle.Render("✏️ Editing Mode")
+	if m.bulkEdit {
+		header = headerStyle.Render(fmt.Sprintf("✏️ Editing %d selected items", len(m.marked)))
+	}
 	footer := keyStyle.Render("tab") + ": " + actionStyle.Render("next field") + " " + bulletStyle.Render("•") + " " + keyStyle.Render("shift+tab") + ": " + actionStyle.Render("prev field") + " " + bulletStyle.Render("•") + " " + keyStyle.Render("enter") + ": " + actionStyle.Render("save") + " " + bulletStyle.Render("•") + " " + keyStyle.Render("esc") + ": " + actionStyle.Render("cancel")
 
 	return lipgloss.JoinVertical(lipgloss.Top,
@@ -1454,10 +2688,12 @@ func loadData() AppData {
 	os.MkdirAll(filepath.Dir(configPath), 0755)
 
 	data := AppData{
+		Version:      dataVersion,
 		Dailies:      []Daily{},
 		RollingTodos: []RollingTodo{},
 		Reminders:    []Reminder{},
 		Glossary:     []GlossaryItem{},
+		Projects:     []Project{},
 	}
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
@@ -1471,24 +2707,36 @@ func loadData() AppData {
 		log.Fatal(err)
 	}
 
+	data.Version = 0 // Configs written before versioning have no version field
 	json.Unmarshal(file, &data)
+	uniqueReminderIDs(data.Reminders)
+	uniqueTodoIDs(data.RollingTodos)
 
-	// Initialize reminders that need parsing
+	// Initialize reminders that were never started. A countdown runs from
+	// when it was created, so one that ran out while lif was closed is caught
+	// up on rather than started over; started ones keep their Elapsed time.
 	for i := range data.Reminders {
 		reminder := &data.Reminders[i]
-		if reminder.TargetTime.IsZero() && reminder.AlarmOrCountdown != "" {
-			if targetTime, isCountdown := parseCountdown(reminder.AlarmOrCountdown); isCountdown {
-				reminder.TargetTime = targetTime
-				reminder.IsCountdown = true
-				reminder.Status = "active"
+		if reminder.TargetTime.IsZero() && reminder.Duration == 0 && reminder.AlarmOrCountdown != "" {
+			if d, isCountdown := countdownDuration(reminder.AlarmOrCountdown); isCountdown {
+				start := reminder.CreatedAt
+				if start.IsZero() {
+					start = time.Now()
+				}
+				reminder.startCountdown(d, start)
 			} else if targetTime, isAlarm := parseAlarmTime(reminder.AlarmOrCountdown); isAlarm {
 				reminder.TargetTime = targetTime
 				reminder.IsCountdown = false
+			} else {
+				continue
+			}
+			if reminder.Status == "" {
 				reminder.Status = "active"
 			}
 		}
 	}
 
+	catchUpCountdowns(&data, time.Now())
 	return data
 }
 
@@ -1512,6 +2760,14 @@ func saveData(data AppData) {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCLI(os.Args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v", err)
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Missed reminders. Reminders that came due while lif was closed aren't fired
+// one after another on startup; they are collected in a digest, announced
+// with a single notification, and each one can be snoozed, rescheduled or
+// dismissed.
+
+// Reminders due less than this long before startup fire as usual
+const missedGrace = time.Minute
+
+const snoozeDuration = 10 * time.Minute
+
+// findMissed returns the indexes of active reminders that should have fired
+// before now, the longest overdue first
+func findMissed(reminders []Reminder, now time.Time) []int {
+	missed := []int{}
+	for i, reminder := range reminders {
+		if reminder.Status == "active" && !reminder.Notified && !reminder.TargetTime.IsZero() && now.Sub(reminder.TargetTime) > missedGrace {
+			missed = append(missed, i)
+		}
+	}
+	sort.SliceStable(missed, func(a, b int) bool {
+		return reminders[missed[a]].TargetTime.Before(reminders[missed[b]].TargetTime)
+	})
+	return missed
+}
+
+// catchUp opens the digest of reminders missed while lif was closed and sends
+// one notification for all of them
+func (m *model) catchUp(now time.Time) {
+	m.missed = findMissed(m.data.Reminders, now)
+	if len(m.missed) == 0 {
+		return
+	}
+	m.missedOpen = true
+	m.missedCursor = 0
+
+	names := []string{}
+	for _, idx := range m.missed {
+		reminder := m.data.Reminders[idx]
+		names = append(names, fmt.Sprintf("%s (%s late)", reminder.Reminder, formatLate(now.Sub(reminder.TargetTime))))
+		m.logItem("missed", 4, idx)
+	}
+	title := "Missed reminder"
+	if len(m.missed) > 1 {
+		title = fmt.Sprintf("%d missed reminders", len(m.missed))
+	}
+	m.notify(nil, "", Notification{Title: title, Message: strings.Join(names, "\n")})
+}
+
+func formatLate(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+	if d >= 48*time.Hour {
+		return fmt.Sprintf("%dd", int(d.Hours())/24)
+	}
+	return formatTracked(d)
+}
+
+// isMissed reports whether a reminder is waiting in the digest, which keeps
+// the tick loop from firing it
+func (m *model) isMissed(idx int) bool {
+	if !m.missedOpen {
+		return false
+	}
+	for _, missed := range m.missed {
+		if missed == idx {
+			return true
+		}
+	}
+	return false
+}
+
+// settleMissed takes the reminder under the cursor out of the digest and
+// closes it once it is empty
+func (m *model) settleMissed() {
+	m.missed = append(m.missed[:m.missedCursor], m.missed[m.missedCursor+1:]...)
+	if m.missedCursor >= len(m.missed) && m.missedCursor > 0 {
+		m.missedCursor--
+	}
+	if len(m.missed) == 0 {
+		m.missedOpen = false
+	}
+	m.tables[2].SetRows(m.reminderRows())
+}
+
+// rescheduleMissed sets a missed reminder going again, as a countdown like
+// 30m or at a time like 14:30
+func (m *model) rescheduleMissed(idx int, value string) bool {
+	reminder := &m.data.Reminders[idx]
+	if d, ok := countdownDuration(value); ok {
+		reminder.startCountdown(d, time.Now())
+	} else if target, ok := parseAlarmTime(value); ok {
+		reminder.TargetTime = target
+		reminder.IsCountdown = false
+	} else {
+		return false
+	}
+	reminder.rearm()
+	return true
+}
+
+// rearm makes a reminder with a new target time fire again
+func (r *Reminder) rearm() {
+	r.Status = "active"
+	r.Notified = false
+	r.PausedRemaining = 0
+}
+
+// dismissMissed marks a missed reminder as fired and acknowledged, so it
+// doesn't ring or repeat
+func (m *model) dismissMissed(idx int) {
+	now := time.Now()
+	reminder := &m.data.Reminders[idx]
+	reminder.Status = "expired"
+	reminder.Notified = true
+	reminder.LastNotified = now
+	reminder.Nags = 0
+	reminder.AcknowledgedAt = now
+	m.logItem("dismissed", 4, idx)
+}
+
+func (m model) handleMissedKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.rescheduling {
+		switch msg.String() {
+		case "esc":
+			m.rescheduling = false
+			return m, nil
+		case "enter":
+			value := strings.TrimSpace(m.rescheduleInput.Value())
+			idx := m.missed[m.missedCursor]
+			if !m.rescheduleMissed(idx, value) {
+				return m, showStatus("❌ Use a countdown like 30m or 2h, or a time like 14:30", "196")
+			}
+			m.rescheduling = false
+			name := m.data.Reminders[idx].Reminder
+			target := m.data.Reminders[idx].TargetTime
+			m.logItem("rescheduled", 4, idx)
+			m.settleMissed()
+			m.save("reschedule " + name)
+			return m, showStatus(fmt.Sprintf("📅 %s rescheduled to %s", name, target.Format("Jan 2 15:04")), "82")
+		}
+		var cmd tea.Cmd
+		m.rescheduleInput, cmd = m.rescheduleInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.missedCursor > 0 {
+			m.missedCursor--
+		}
+	case "down", "j":
+		if m.missedCursor < len(m.missed)-1 {
+			m.missedCursor++
+		}
+	case "s":
+		idx := m.missed[m.missedCursor]
+		name := m.data.Reminders[idx].Reminder
+		m.data.Reminders[idx].startCountdown(snoozeDuration, time.Now())
+		m.data.Reminders[idx].rearm()
+		m.logItem("snoozed", 4, idx)
+		m.settleMissed()
+		m.save("snooze " + name)
+		return m, showStatus(fmt.Sprintf("💤 Snoozed %s for %s", name, formatTracked(snoozeDuration)), "82")
+	case "r":
+		m.rescheduling = true
+		m.rescheduleInput = textinput.New()
+		m.rescheduleInput.Prompt = "Reschedule to (e.g. 30m, 2h, 14:30): "
+		m.rescheduleInput.Focus()
+	case "d", "enter":
+		idx := m.missed[m.missedCursor]
+		name := m.data.Reminders[idx].Reminder
+		m.dismissMissed(idx)
+		m.settleMissed()
+		m.save("dismiss " + name)
+		return m, showStatus("✓ Dismissed "+name, "86")
+	case "D", "esc", "q":
+		// Closing the digest dismisses whatever is left in it
+		count := len(m.missed)
+		for _, idx := range m.missed {
+			m.dismissMissed(idx)
+		}
+		m.missed = nil
+		m.missedOpen = false
+		m.tables[2].SetRows(m.reminderRows())
+		m.save("dismiss missed reminders")
+		return m, showStatus(fmt.Sprintf("✓ Dismissed %d missed reminders", count), "86")
+	}
+	return m, nil
+}
+
+func (m model) missedView() string {
+	now := time.Now()
+	header := headerStyle.Render(fmt.Sprintf("⏰ Missed while lif was closed (%d)", len(m.missed)))
+
+	lines := []string{}
+	for i, idx := range m.missed {
+		reminder := m.data.Reminders[idx]
+		line := fmt.Sprintf("%-30s due %s  %s", reminder.Reminder, reminder.TargetTime.Format("Jan 2 15:04"),
+			statusOverdueStyle.Render(formatLate(now.Sub(reminder.TargetTime))+" late"))
+		if reminder.Note != "" {
+			line += " " + bulletStyle.Render(reminder.Note)
+		}
+		if i == m.missedCursor {
+			line = activeTabStyle.Render(">") + " " + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	if m.rescheduling {
+		lines = append(lines, "", "> "+m.rescheduleInput.View())
+	}
+
+	footer := keyStyle.Render("↑↓") + ": " + actionStyle.Render("navigate") + bulletStyle.Render(" • ") +
+		keyStyle.Render("s") + ": " + actionStyle.Render("snooze "+formatTracked(snoozeDuration)) + bulletStyle.Render(" • ") +
+		keyStyle.Render("r") + ": " + actionStyle.Render("reschedule") + bulletStyle.Render(" • ") +
+		keyStyle.Render("d") + ": " + actionStyle.Render("dismiss") + bulletStyle.Render(" • ") +
+		keyStyle.Render("esc") + ": " + actionStyle.Render("dismiss all")
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		header,
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		footer,
+	)
+}
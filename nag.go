@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Repeating alerts. A fired reminder with NagMinutes set notifies again every
+// NagMinutes until it is acknowledged, getting more urgent and louder each
+// time. Acknowledgements from `lif ack` are appended to acks.jsonl, which the
+// TUI checks before every repeat, so they count even while lif is running and
+// would otherwise overwrite config.json.
+
+type Acknowledgement struct {
+	ID         int       `json:"id"`
+	TargetTime time.Time `json:"target_time"`
+	Time       time.Time `json:"time"`
+}
+
+// Repeats after which a nag is sent as critical
+const criticalNags = 2
+
+// acknowledged reports whether the reminder was acknowledged since it last
+// fired
+func (r Reminder) acknowledged() bool {
+	return !r.AcknowledgedAt.IsZero() && !r.AcknowledgedAt.Before(r.TargetTime)
+}
+
+// nagging reports whether the reminder still waits to be acknowledged
+func (r Reminder) nagging() bool {
+	return r.Status == "expired" && r.NagMinutes > 0 && !r.acknowledged()
+}
+
+func (r Reminder) nagDue(now time.Time) bool {
+	return r.nagging() && now.Sub(r.LastNotified) >= time.Duration(r.NagMinutes)*time.Minute
+}
+
+// escalatedVolume raises the volume halfway to the maximum on the first
+// repeat and to the maximum after that
+func escalatedVolume(volume, level int) int {
+	switch {
+	case level <= 0:
+		return volume
+	case level == 1:
+		return volume + (100-volume)/2
+	}
+	return 100
+}
+
+func acksPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "lif", "acks.jsonl"), nil
+}
+
+func appendAcknowledgements(acks ...Acknowledgement) error {
+	path, err := acksPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	for _, ack := range acks {
+		line, err := json.Marshal(ack)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadAcknowledgements() []Acknowledgement {
+	path, err := acksPath()
+	if err != nil {
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	acks := []Acknowledgement{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var ack Acknowledgement
+		if json.Unmarshal(scanner.Bytes(), &ack) == nil {
+			acks = append(acks, ack)
+		}
+	}
+	return acks
+}
+
+// pruneAcknowledgements rewrites acks.jsonl with only the acknowledgements
+// still waiting for a repeating reminder, dropping the applied and stale ones
+func pruneAcknowledgements(acks []Acknowledgement, reminders []Reminder) error {
+	keep := []Acknowledgement{}
+	for _, ack := range acks {
+		for _, reminder := range reminders {
+			if reminder.nagging() && ack.ID == reminder.ID && ack.TargetTime.Equal(reminder.TargetTime) {
+				keep = append(keep, ack)
+				break
+			}
+		}
+	}
+	if len(keep) == len(acks) {
+		return nil
+	}
+	path, err := acksPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(keep) == 0 {
+		return nil
+	}
+	return appendAcknowledgements(keep...)
+}
+
+// findAcknowledgement returns when a reminder firing was acknowledged from
+// the CLI, or the zero time
+func findAcknowledgement(acks []Acknowledgement, reminder Reminder) time.Time {
+	for _, ack := range acks {
+		if ack.ID == reminder.ID && ack.TargetTime.Equal(reminder.TargetTime) {
+			return ack.Time
+		}
+	}
+	return time.Time{}
+}
+
+// checkNags repeats the notification of fired reminders that haven't been
+// acknowledged, once their interval has passed
+func (m *model) checkNags(now time.Time) {
+	var acks []Acknowledgement
+	loaded := false
+	changed := false
+	for i := range m.data.Reminders {
+		reminder := &m.data.Reminders[i]
+		if !reminder.nagDue(now) {
+			continue
+		}
+		if !loaded {
+			acks = loadAcknowledgements()
+			loaded = true
+		}
+		changed = true
+		if ack := findAcknowledgement(acks, *reminder); !ack.IsZero() {
+			reminder.AcknowledgedAt = ack
+			m.logItem("acknowledged", 4, i)
+			continue
+		}
+
+		reminder.Nags++
+		reminder.LastNotified = now
+		m.logItem("nagged", 4, i)
+		late := now.Sub(reminder.TargetTime).Round(time.Minute)
+		m.notify(reminder.Notify, reminder.Sound, Notification{
+			Title:        fmt.Sprintf("Reminder (%s ago)", formatDuration(late)),
+			Message:      reminder.Reminder,
+			Level:        reminder.Nags,
+			ReminderID:   reminder.ID,
+			BreakThrough: reminder.BreakDND,
+		})
+		m.statusMsg = fmt.Sprintf("🔁 Reminder: %s (A to acknowledge)", reminder.Reminder)
+		m.statusColor = "196"
+		m.statusExpiry = time.Now().Add(5 * time.Second)
+	}
+	if changed {
+		m.saveQuiet()
+	}
+	if loaded {
+		pruneAcknowledgements(acks, m.data.Reminders)
+	}
+}
+
+// acknowledge stops a fired reminder from repeating
+func (m *model) acknowledge(idx int) bool {
+	reminder := &m.data.Reminders[idx]
+	if reminder.Status != "expired" || reminder.acknowledged() {
+		return false
+	}
+	reminder.AcknowledgedAt = time.Now()
+	m.logItem("acknowledged", 4, idx)
+	return true
+}
+
+// acknowledgeSelected acknowledges the selected reminder, or on the Home tab
+// every reminder that is still repeating
+func (m *model) acknowledgeSelected() {
+	count := 0
+	if m.activeTab == 4 {
+		if idx := m.selectedIndex(2); idx != -1 && m.acknowledge(idx) {
+			count++
+		}
+	} else {
+		for i, reminder := range m.data.Reminders {
+			if reminder.nagging() && m.acknowledge(i) {
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		m.statusMsg = "Nothing to acknowledge"
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(2 * time.Second)
+		return
+	}
+	m.tables[2].SetRows(m.reminderRows())
+	m.save("acknowledge")
+	m.statusMsg = fmt.Sprintf("✅ Acknowledged %d reminders", count)
+	if count == 1 {
+		m.statusMsg = "✅ Acknowledged reminder"
+	}
+	m.statusColor = "82"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
+
+// acknowledgeAlert acknowledges the reminder behind a dismissed modal alert
+func (m *model) acknowledgeAlert(alert Notification) {
+	if alert.ReminderID == 0 {
+		return
+	}
+	acknowledged := false
+	for i, reminder := range m.data.Reminders {
+		if reminder.ID == alert.ReminderID && reminder.nagging() && m.acknowledge(i) {
+			acknowledged = true
+		}
+	}
+	if acknowledged {
+		m.tables[2].SetRows(m.reminderRows())
+		m.save("acknowledge")
+	}
+}
+
+func (m *model) naggingCount() int {
+	count := 0
+	for _, reminder := range m.data.Reminders {
+		if reminder.nagging() {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUniqueReminderIDs(t *testing.T) {
+	// Numbered by count: deleting #2 of three and adding one gave two #3s
+	reminders := []Reminder{{ID: 1}, {ID: 3}, {ID: 3}, {ID: 0}}
+	uniqueReminderIDs(reminders)
+
+	seen := map[int]bool{}
+	for _, reminder := range reminders {
+		if reminder.ID <= 0 || seen[reminder.ID] {
+			t.Fatalf("IDs not unique: %+v", reminders)
+		}
+		seen[reminder.ID] = true
+	}
+	if reminders[0].ID != 1 || reminders[1].ID != 3 {
+		t.Errorf("unique IDs changed: %+v", reminders)
+	}
+	if got := nextReminderID(reminders); got != 6 {
+		t.Errorf("nextReminderID = %d, want 6", got)
+	}
+}
+
+func TestPruneAcknowledgements(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	fired := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	reminders := []Reminder{
+		{ID: 1, Status: "expired", NagMinutes: 5, TargetTime: fired},
+		{ID: 2, Status: "expired", NagMinutes: 5, TargetTime: fired, AcknowledgedAt: fired.Add(time.Minute)},
+	}
+	acks := []Acknowledgement{
+		{ID: 1, TargetTime: fired, Time: fired.Add(time.Minute)},                     // still waiting
+		{ID: 2, TargetTime: fired, Time: fired.Add(time.Minute)},                     // applied
+		{ID: 1, TargetTime: fired.Add(-24 * time.Hour), Time: fired.Add(-time.Hour)}, // earlier firing
+		{ID: 9, TargetTime: fired, Time: fired},                                      // deleted reminder
+	}
+	if err := appendAcknowledgements(acks...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pruneAcknowledgements(loadAcknowledgements(), reminders); err != nil {
+		t.Fatal(err)
+	}
+	left := loadAcknowledgements()
+	if len(left) != 1 || left[0].ID != 1 || !left[0].TargetTime.Equal(fired) {
+		t.Errorf("kept %+v, want only the waiting acknowledgement", left)
+	}
+
+	reminders[0].AcknowledgedAt = fired.Add(time.Minute)
+	if err := pruneAcknowledgements(loadAcknowledgements(), reminders); err != nil {
+		t.Fatal(err)
+	}
+	if left := loadAcknowledgements(); len(left) != 0 {
+		t.Errorf("kept %+v after every acknowledgement was applied", left)
+	}
+}
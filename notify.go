@@ -0,0 +1,472 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/godbus/dbus/v5"
+)
+
+// Notification backends. Each reminder can pick the backends it is delivered
+// through; the rest use the defaults from the "notifications" section of
+// config.json. Backends run concurrently so a slow webhook or mail server
+// never holds up the UI.
+
+type Notification struct {
+	Title   string
+	Message string
+	// Times the notification was repeated; urgency and volume rise with it
+	Level int
+	// Reminder the notification is for, acknowledged when a modal alert is
+	// dismissed
+	ReminderID int
+	// Delivered even during Do Not Disturb
+	BreakThrough bool
+}
+
+func (n Notification) critical() bool {
+	return n.Level >= criticalNags
+}
+
+// A Notifier delivers notifications through one channel
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+type NotifySettings struct {
+	// Backends used by reminders that don't choose their own
+	Default []string `json:"default,omitempty"`
+	// Command run by the command backend; the title and message are passed
+	// in LIF_TITLE and LIF_MESSAGE
+	Command []string        `json:"command,omitempty"`
+	Webhook WebhookSettings `json:"webhook,omitempty"`
+	SMTP    SMTPSettings    `json:"smtp,omitempty"`
+	Sound   SoundSettings   `json:"sound,omitempty"`
+	// Hold notifications back, see dnd.go
+	DoNotDisturb bool `json:"do_not_disturb,omitempty"`
+	// Do Not Disturb between these times, e.g. "22:00-07:00"
+	QuietHours string `json:"quiet_hours,omitempty"`
+}
+
+type WebhookSettings struct {
+	URL string `json:"url,omitempty"`
+	// ntfy (default): message body with a Title header; gotify: JSON message
+	// with X-Gotify-Key; json: {"title", "message"}
+	Format string `json:"format,omitempty"`
+	Token  string `json:"token,omitempty"`
+}
+
+type SMTPSettings struct {
+	Addr     string   `json:"addr,omitempty"` // host:port, localhost:25 by default
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+}
+
+var notifierNames = []string{"desktop", "bell", "modal", "command", "webhook", "email"}
+
+func (s NotifySettings) defaults() []string {
+	if len(s.Default) == 0 {
+		return []string{"desktop"}
+	}
+	return s.Default
+}
+
+// newNotifier builds a backend by name. Modal alerts are sent to events and
+// are only available in the TUI.
+func newNotifier(name string, settings NotifySettings, events chan<- tea.Msg) (Notifier, error) {
+	switch name {
+	case "desktop":
+		return desktopNotifier{}, nil
+	case "bell":
+		return bellNotifier{events: events}, nil
+	case "modal":
+		if events == nil {
+			return nil, fmt.Errorf("modal notifications only work in the TUI")
+		}
+		return modalNotifier{events: events}, nil
+	case "command":
+		if len(settings.Command) == 0 {
+			return nil, fmt.Errorf("no notification command configured")
+		}
+		return commandNotifier{argv: settings.Command}, nil
+	case "webhook":
+		if settings.Webhook.URL == "" {
+			return nil, fmt.Errorf("no webhook URL configured")
+		}
+		return webhookNotifier{settings.Webhook}, nil
+	case "email":
+		if len(settings.SMTP.To) == 0 {
+			return nil, fmt.Errorf("no email recipients configured")
+		}
+		return smtpNotifier{settings.SMTP}, nil
+	}
+	return nil, fmt.Errorf("unknown notification backend %q", name)
+}
+
+// parseNotifiers reads a comma separated list of backend names
+func parseNotifiers(input string) ([]string, error) {
+	names := []string{}
+	for _, field := range strings.Split(input, ",") {
+		name := strings.ToLower(strings.TrimSpace(field))
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, n := range notifierNames {
+			if n == name {
+				known = true
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown notification backend %q (use %s)", name, strings.Join(notifierNames, ", "))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+type notifyErrorMsg struct {
+	backend string
+	err     error
+}
+
+type alertMsg Notification
+
+// waitForEvent delivers the next modal alert or backend error to Update
+func waitForEvent(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-events
+	}
+}
+
+// notify plays the sound and fans the notification out to the given backends,
+// or the configured defaults when none are given. Failures come back as
+// notifyErrorMsg.
+func (m *model) notify(backends []string, sound string, n Notification) {
+	if !n.BreakThrough && m.dndActive(time.Now()) {
+		m.hold(n, time.Now())
+		return
+	}
+	soundSettings := m.data.Notify.Sound
+	soundSettings.Volume = escalatedVolume(soundSettings.volume(), n.Level)
+	if !playSound(soundSettings, sound) {
+		m.beep()
+	}
+	if len(backends) == 0 {
+		backends = m.data.Notify.defaults()
+	}
+	events := m.events
+	for _, name := range backends {
+		notifier, err := newNotifier(name, m.data.Notify, events)
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("❌ %s notification: %v", name, err)
+			m.statusColor = "196"
+			m.statusExpiry = time.Now().Add(5 * time.Second)
+			continue
+		}
+		go func() {
+			if err := notifier.Notify(n); err != nil {
+				events <- notifyErrorMsg{backend: name, err: err}
+			}
+		}()
+	}
+}
+
+// ringBell rings the terminal bell. bubbletea owns the terminal, so the bell
+// is sent at the start of the next frame instead of being written to stdout;
+// the renderer only writes lines that changed, so it rings once and the next
+// tick takes it out again.
+func (m *model) ringBell() {
+	m.bell = true
+}
+
+// commandSpec is a program to run with its arguments and extra environment
+type commandSpec struct {
+	Name string
+	Args []string
+	Env  []string
+}
+
+func (c commandSpec) command() *exec.Cmd {
+	cmd := exec.Command(c.Name, c.Args...)
+	if len(c.Env) > 0 {
+		cmd.Env = append(os.Environ(), c.Env...)
+	}
+	return cmd
+}
+
+// Markup understood by notification daemons in the body text
+var notifyMarkup = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// dbusNotifyArgs are the arguments of org.freedesktop.Notifications.Notify
+// for n: app name, replaced ID, icon, summary, body, actions, hints and
+// expiry (-1 leaves it to the daemon)
+func dbusNotifyArgs(n Notification) []any {
+	urgency := byte(1)
+	if n.critical() {
+		urgency = 2
+	}
+	// D-Bus strings can't contain NUL
+	title := strings.ReplaceAll(n.Title, "\x00", "")
+	message := strings.ReplaceAll(n.Message, "\x00", "")
+	return []any{"lif", uint32(0), "", title, notifyMarkup.Replace(message), []string{},
+		map[string]dbus.Variant{"urgency": dbus.MakeVariant(urgency)}, int32(-1)}
+}
+
+// dbusNotify shows n through the notification daemon on the session bus
+func dbusNotify(n Notification) error {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return err
+	}
+	daemon := conn.Object("org.freedesktop.Notifications", "/org/freedesktop/Notifications")
+	return daemon.Call("org.freedesktop.Notifications.Notify", 0, dbusNotifyArgs(n)...).Err
+}
+
+// desktopCommand builds the command that shows n on goos. The text is never
+// part of a script: AppleScript gets it as run arguments and PowerShell
+// through environment variables, so quotes in a reminder can't break out.
+func desktopCommand(goos string, n Notification) (commandSpec, error) {
+	// Arguments and environment values can't contain NUL
+	title := strings.ReplaceAll(n.Title, "\x00", "")
+	message := strings.ReplaceAll(n.Message, "\x00", "")
+
+	switch goos {
+	case "darwin":
+		return commandSpec{Name: "osascript", Args: []string{
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			"--", title, message,
+		}}, nil
+	case "windows":
+		return commandSpec{
+			Name: "powershell",
+			Args: []string{"-NoProfile", "-NonInteractive", "-Command",
+				"[System.Reflection.Assembly]::LoadWithPartialName('System.Windows.Forms') | Out-Null; " +
+					"[System.Windows.Forms.MessageBox]::Show($env:LIF_MESSAGE, $env:LIF_TITLE) | Out-Null"},
+			Env: []string{"LIF_TITLE=" + title, "LIF_MESSAGE=" + message},
+		}, nil
+	}
+	return commandSpec{}, fmt.Errorf("desktop notifications are not supported on %s", goos)
+}
+
+type desktopNotifier struct{}
+
+func (desktopNotifier) Notify(n Notification) error {
+	if runtime.GOOS == "linux" {
+		return dbusNotify(n)
+	}
+	spec, err := desktopCommand(runtime.GOOS, n)
+	if err != nil {
+		return err
+	}
+	return spec.command().Run()
+}
+
+// bellNotifier rings the terminal bell. In the TUI the bell goes out with
+// the next frame (see ringBell) rather than being written over bubbletea's
+// output.
+type bellNotifier struct {
+	events chan<- tea.Msg
+}
+
+type bellMsg struct{}
+
+func (n bellNotifier) Notify(Notification) error {
+	if n.events == nil {
+		_, err := os.Stdout.WriteString("\a")
+		return err
+	}
+	n.events <- bellMsg{}
+	return nil
+}
+
+type modalNotifier struct {
+	events chan<- tea.Msg
+}
+
+func (n modalNotifier) Notify(notification Notification) error {
+	n.events <- alertMsg(notification)
+	return nil
+}
+
+type commandNotifier struct {
+	argv []string
+}
+
+// spec passes the text in the environment, never on the command line
+func (n commandNotifier) spec(notification Notification) commandSpec {
+	return commandSpec{
+		Name: n.argv[0],
+		Args: n.argv[1:],
+		Env: []string{
+			"LIF_TITLE=" + strings.ReplaceAll(notification.Title, "\x00", ""),
+			"LIF_MESSAGE=" + strings.ReplaceAll(notification.Message, "\x00", ""),
+		},
+	}
+}
+
+func (n commandNotifier) Notify(notification Notification) error {
+	if out, err := n.spec(notification).command().CombinedOutput(); err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+type webhookNotifier struct {
+	WebhookSettings
+}
+
+func (n webhookNotifier) Notify(notification Notification) error {
+	var req *http.Request
+	var err error
+	switch n.Format {
+	case "gotify":
+		priority := 5
+		if notification.critical() {
+			priority = 8
+		}
+		body, _ := json.Marshal(map[string]any{"title": notification.Title, "message": notification.Message, "priority": priority})
+		if req, err = http.NewRequest("POST", n.URL, bytes.NewReader(body)); err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Gotify-Key", n.Token)
+	case "json":
+		urgency := "normal"
+		if notification.critical() {
+			urgency = "critical"
+		}
+		body, _ := json.Marshal(map[string]string{"title": notification.Title, "message": notification.Message, "urgency": urgency})
+		if req, err = http.NewRequest("POST", n.URL, bytes.NewReader(body)); err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+	case "", "ntfy":
+		if req, err = http.NewRequest("POST", n.URL, strings.NewReader(notification.Message)); err != nil {
+			return err
+		}
+		// Header values can't hold newlines or non-ASCII text as is
+		req.Header.Set("Title", mime.QEncoding.Encode("utf-8", oneLine(notification.Title)))
+		if notification.critical() {
+			req.Header.Set("Priority", "urgent")
+		}
+	default:
+		return fmt.Errorf("unknown webhook format %q", n.Format)
+	}
+	if n.Token != "" && n.Format != "gotify" {
+		req.Header.Set("Authorization", "Bearer "+n.Token)
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+type smtpNotifier struct {
+	SMTPSettings
+}
+
+func (n smtpNotifier) from() string {
+	if n.From == "" {
+		return "lif@localhost"
+	}
+	return n.From
+}
+
+// message builds the mail. The subject is a single encoded line, so text in
+// the title can't add headers.
+func (n smtpNotifier) message(notification Notification) string {
+	from := n.from()
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", oneLine(notification.Title)))
+	if notification.critical() {
+		msg.WriteString("X-Priority: 1\r\nImportance: high\r\n")
+	}
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(notification.Message, "\r\n", "\n"), "\n", "\r\n"))
+	msg.WriteString("\r\n")
+	return msg.String()
+}
+
+func (n smtpNotifier) Notify(notification Notification) error {
+	addr := n.Addr
+	if addr == "" {
+		addr = "localhost:25"
+	}
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		host := addr
+		if i := strings.LastIndex(addr, ":"); i != -1 {
+			host = addr[:i]
+		}
+		auth = smtp.PlainAuth("", n.Username, n.Password, host)
+	}
+	return smtp.SendMail(addr, auth, n.from(), n.To, []byte(n.message(notification)))
+}
+
+var alertStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("226")).
+	Padding(1, 3)
+
+func (m model) handleAlertKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc", " ", "q":
+		alert := m.alerts[0]
+		m.alerts = m.alerts[1:]
+		m.acknowledgeAlert(alert)
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// alertView shows the oldest modal alert in the middle of the screen
+func (m model) alertView() string {
+	alert := m.alerts[0]
+	body := lipgloss.JoinVertical(lipgloss.Center,
+		headerStyle.Render("🔔 "+alert.Title),
+		"",
+		alert.Message,
+		"",
+		keyStyle.Render("enter")+": "+actionStyle.Render("dismiss"),
+	)
+	if len(m.alerts) > 1 {
+		body = lipgloss.JoinVertical(lipgloss.Center, body, bulletStyle.Render(fmt.Sprintf("%d more", len(m.alerts)-1)))
+	}
+	style := alertStyle
+	if alert.critical() {
+		style = style.BorderForeground(lipgloss.Color("196"))
+	}
+	box := style.Render(body)
+	if m.width == 0 || m.height == 0 {
+		return box
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
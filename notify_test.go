@@ -0,0 +1,282 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/godbus/dbus/v5"
+)
+
+// Reminder text that would break out of a shell, AppleScript or PowerShell
+// string, or a mail or HTTP header, if it were spliced in
+var hostileTexts = []struct {
+	name    string
+	title   string
+	message string
+}{
+	{"double quotes", `Say "hi"`, `He said "stop" and \"left\"`},
+	{"single quotes", "it's", "don't'; rm -rf ~; echo '"},
+	{"newlines", "line one\nline two", "first\nsecond\r\nthird\n"},
+	{"command substitution", "$(touch /tmp/lif-pwned)", "`id` $(id) ${HOME} $env:PATH"},
+	{"unicode", "Café ☕ 日本語", "Ünïcödé — 🎉 ñ"},
+	{"leading dash", "-u critical", "--help"},
+	{"markup", "<b>bold</b>", "a & b < c > d"},
+}
+
+func TestDesktopCommandKeepsTextOutOfScripts(t *testing.T) {
+	for _, tc := range hostileTexts {
+		n := Notification{Title: tc.title, Message: tc.message}
+
+		t.Run("darwin/"+tc.name, func(t *testing.T) {
+			spec, err := desktopCommand("darwin", n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			end := slices.Index(spec.Args, "--")
+			if end == -1 {
+				t.Fatalf("no -- before the text in %q", spec.Args)
+			}
+			for _, arg := range spec.Args[:end] {
+				if strings.Contains(arg, tc.title) || strings.Contains(arg, tc.message) {
+					t.Errorf("script %q contains the text", arg)
+				}
+			}
+			if got, want := spec.Args[end+1:], []string{tc.title, tc.message}; !slices.Equal(got, want) {
+				t.Errorf("run arguments = %q, want %q", got, want)
+			}
+		})
+
+		t.Run("windows/"+tc.name, func(t *testing.T) {
+			spec, err := desktopCommand("windows", n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, arg := range spec.Args {
+				if strings.Contains(arg, tc.title) || strings.Contains(arg, tc.message) {
+					t.Errorf("script %q contains the text", arg)
+				}
+			}
+			want := []string{"LIF_TITLE=" + tc.title, "LIF_MESSAGE=" + tc.message}
+			if !slices.Equal(spec.Env, want) {
+				t.Errorf("env = %q, want %q", spec.Env, want)
+			}
+		})
+	}
+}
+
+func TestDBusNotifyArgs(t *testing.T) {
+	for _, tc := range hostileTexts {
+		t.Run(tc.name, func(t *testing.T) {
+			args := dbusNotifyArgs(Notification{Title: tc.title, Message: tc.message})
+			if len(args) != 8 {
+				t.Fatalf("%d arguments, Notify takes 8", len(args))
+			}
+			if args[3] != tc.title {
+				t.Errorf("summary = %q, want %q", args[3], tc.title)
+			}
+			if want := notifyMarkup.Replace(tc.message); args[4] != want {
+				t.Errorf("body = %q, want %q", args[4], want)
+			}
+		})
+	}
+
+	args := dbusNotifyArgs(Notification{Title: "a\x00b", Message: "c\x00d"})
+	if args[3] != "ab" || args[4] != "cd" {
+		t.Errorf("NUL not stripped: %q / %q", args[3], args[4])
+	}
+}
+
+func TestDBusNotifyUrgency(t *testing.T) {
+	for _, tc := range []struct {
+		level int
+		want  byte
+	}{{0, 1}, {criticalNags, 2}} {
+		hints := dbusNotifyArgs(Notification{Level: tc.level})[6].(map[string]dbus.Variant)
+		if got := hints["urgency"].Value(); got != tc.want {
+			t.Errorf("level %d: urgency = %v, want %d", tc.level, got, tc.want)
+		}
+	}
+}
+
+func TestDesktopCommandStripsNUL(t *testing.T) {
+	for _, goos := range []string{"darwin", "windows"} {
+		spec, err := desktopCommand(goos, Notification{Title: "a\x00b", Message: "c\x00d"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, value := range append(spec.Args, spec.Env...) {
+			if strings.ContainsRune(value, 0) {
+				t.Errorf("%s: %q contains NUL", goos, value)
+			}
+		}
+	}
+}
+
+func TestDesktopCommandUnsupported(t *testing.T) {
+	// Linux goes over D-Bus rather than a command
+	for _, goos := range []string{"linux", "plan9"} {
+		if _, err := desktopCommand(goos, Notification{}); err == nil {
+			t.Errorf("expected an error for %s", goos)
+		}
+	}
+}
+
+func TestBellNotifierSendsToTUI(t *testing.T) {
+	events := make(chan tea.Msg, 1)
+	if err := (bellNotifier{events: events}).Notify(Notification{}); err != nil {
+		t.Fatal(err)
+	}
+	if msg := <-events; msg != (bellMsg{}) {
+		t.Errorf("got %#v, want bellMsg", msg)
+	}
+}
+
+func TestCommandNotifierPassesTextInEnv(t *testing.T) {
+	notifier := commandNotifier{argv: []string{"sh", "-c", `echo "$LIF_TITLE: $LIF_MESSAGE"`}}
+	for _, tc := range hostileTexts {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := notifier.spec(Notification{Title: tc.title, Message: tc.message})
+			if spec.Name != "sh" || !slices.Equal(spec.Args, notifier.argv[1:]) {
+				t.Errorf("command = %s %q, want the configured argv unchanged", spec.Name, spec.Args)
+			}
+			want := []string{"LIF_TITLE=" + tc.title, "LIF_MESSAGE=" + tc.message}
+			if !slices.Equal(spec.Env, want) {
+				t.Errorf("env = %q, want %q", spec.Env, want)
+			}
+		})
+	}
+}
+
+// webhookRequest sends n through a webhook of the given format and returns
+// the request the server received
+func webhookRequest(t *testing.T, format string, n Notification) (http.Header, []byte) {
+	t.Helper()
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	notifier := webhookNotifier{WebhookSettings{URL: server.URL, Format: format, Token: "secret"}}
+	if err := notifier.Notify(n); err != nil {
+		t.Fatal(err)
+	}
+	return header, body
+}
+
+func TestWebhookNotifierEncodesText(t *testing.T) {
+	decoder := new(mime.WordDecoder)
+	for _, tc := range hostileTexts {
+		n := Notification{Title: tc.title, Message: tc.message}
+
+		t.Run("ntfy/"+tc.name, func(t *testing.T) {
+			header, body := webhookRequest(t, "ntfy", n)
+			if string(body) != tc.message {
+				t.Errorf("body = %q, want %q", body, tc.message)
+			}
+			title, err := decoder.DecodeHeader(header.Get("Title"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := oneLine(tc.title); title != want {
+				t.Errorf("Title header decodes to %q, want %q", title, want)
+			}
+			if got := header.Get("Authorization"); got != "Bearer secret" {
+				t.Errorf("Authorization = %q", got)
+			}
+		})
+
+		for _, format := range []string{"gotify", "json"} {
+			t.Run(format+"/"+tc.name, func(t *testing.T) {
+				header, body := webhookRequest(t, format, n)
+				var got struct {
+					Title   string `json:"title"`
+					Message string `json:"message"`
+				}
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("body %q: %v", body, err)
+				}
+				if got.Title != tc.title || got.Message != tc.message {
+					t.Errorf("body = %+v, want %q / %q", got, tc.title, tc.message)
+				}
+				if got := header.Get("Content-Type"); got != "application/json" {
+					t.Errorf("Content-Type = %q", got)
+				}
+			})
+		}
+	}
+}
+
+func TestWebhookNotifierUrgency(t *testing.T) {
+	header, _ := webhookRequest(t, "ntfy", Notification{Title: "t", Message: "m", Level: criticalNags})
+	if got := header.Get("Priority"); got != "urgent" {
+		t.Errorf("Priority = %q, want urgent", got)
+	}
+	header, _ = webhookRequest(t, "gotify", Notification{Title: "t", Message: "m"})
+	if got := header.Get("X-Gotify-Key"); got != "secret" {
+		t.Errorf("X-Gotify-Key = %q", got)
+	}
+}
+
+func TestSMTPMessageEncodesSubject(t *testing.T) {
+	decoder := new(mime.WordDecoder)
+	notifier := smtpNotifier{SMTPSettings{To: []string{"me@example.com"}}}
+	for _, tc := range hostileTexts {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := notifier.message(Notification{Title: tc.title, Message: tc.message})
+			head, body, ok := strings.Cut(msg, "\r\n\r\n")
+			if !ok {
+				t.Fatalf("no blank line after the headers in %q", msg)
+			}
+
+			headers := strings.Split(head, "\r\n")
+			names := []string{}
+			subject := ""
+			for _, line := range headers {
+				name, value, _ := strings.Cut(line, ": ")
+				names = append(names, name)
+				if name == "Subject" {
+					subject = value
+				}
+				for _, r := range line {
+					if r > 0x7e || r == '\n' || r == '\r' {
+						t.Errorf("header line %q is not plain ASCII", line)
+						break
+					}
+				}
+			}
+			want := []string{"From", "To", "Subject", "MIME-Version", "Content-Type"}
+			if !slices.Equal(names, want) {
+				t.Errorf("headers = %q, want %q", names, want)
+			}
+			decoded, err := decoder.DecodeHeader(subject)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded != oneLine(tc.title) {
+				t.Errorf("subject decodes to %q, want %q", decoded, oneLine(tc.title))
+			}
+
+			wantBody := strings.ReplaceAll(strings.ReplaceAll(tc.message, "\r\n", "\n"), "\n", "\r\n") + "\r\n"
+			if body != wantBody {
+				t.Errorf("body = %q, want %q", body, wantBody)
+			}
+		})
+	}
+}
+
+func TestSMTPMessageUrgency(t *testing.T) {
+	msg := smtpNotifier{}.message(Notification{Title: "t", Message: "m", Level: criticalNags})
+	if !strings.Contains(msg, "X-Priority: 1\r\n") {
+		t.Errorf("critical mail has no X-Priority header: %q", msg)
+	}
+}
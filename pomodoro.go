@@ -0,0 +1,514 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Pomodoro timer. Phases run on the countdown clock like countdown reminders
+// (see countdown.go): an active phase counts running time on every tick, a
+// paused one keeps its remaining time. When a phase ends the next one starts
+// on its own and a notification is sent. A phase that runs out while lif is
+// closed or the computer is asleep stops instead, and a work session that
+// ended that way isn't counted.
+
+type PomodoroSettings struct {
+	WorkMinutes       int `json:"work_minutes"`
+	ShortBreakMinutes int `json:"short_break_minutes"`
+	LongBreakMinutes  int `json:"long_break_minutes"`
+	// Work sessions before a long break
+	LongBreakEvery int `json:"long_break_every"`
+}
+
+type Pomodoro struct {
+	Settings PomodoroSettings `json:"settings"`
+	Phase    string           `json:"phase,omitempty"`  // work, short_break or long_break
+	Status   string           `json:"status,omitempty"` // active or paused; empty when stopped
+	// Where the running phase ends on the wall clock
+	TargetTime      time.Time     `json:"target_time"`
+	PausedRemaining time.Duration `json:"paused_remaining,omitempty"`
+	Countdown
+	// Work sessions finished since the last long break
+	Streak int `json:"streak,omitempty"`
+	// Rolling todo the sessions count towards
+	TodoID int `json:"todo_id,omitempty"`
+	// Finished work sessions per day (2006-01-02)
+	Counts map[string]int `json:"counts,omitempty"`
+}
+
+var defaultPomodoroSettings = PomodoroSettings{
+	WorkMinutes:       25,
+	ShortBreakMinutes: 5,
+	LongBreakMinutes:  15,
+	LongBreakEvery:    4,
+}
+
+var pomodoroPhaseNames = map[string]string{
+	"work":        "Work",
+	"short_break": "Short break",
+	"long_break":  "Long break",
+}
+
+var (
+	pomodoroWorkStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+	pomodoroBreakStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("82")).Bold(true)
+)
+
+// settings fills in defaults for anything not configured
+func (p *Pomodoro) settings() PomodoroSettings {
+	s := p.Settings
+	if s.WorkMinutes <= 0 {
+		s.WorkMinutes = defaultPomodoroSettings.WorkMinutes
+	}
+	if s.ShortBreakMinutes <= 0 {
+		s.ShortBreakMinutes = defaultPomodoroSettings.ShortBreakMinutes
+	}
+	if s.LongBreakMinutes <= 0 {
+		s.LongBreakMinutes = defaultPomodoroSettings.LongBreakMinutes
+	}
+	if s.LongBreakEvery <= 0 {
+		s.LongBreakEvery = defaultPomodoroSettings.LongBreakEvery
+	}
+	return s
+}
+
+func (p *Pomodoro) phase() string {
+	if p.Phase == "" {
+		return "work"
+	}
+	return p.Phase
+}
+
+func (p *Pomodoro) length(phase string) time.Duration {
+	s := p.settings()
+	switch phase {
+	case "short_break":
+		return time.Duration(s.ShortBreakMinutes) * time.Minute
+	case "long_break":
+		return time.Duration(s.LongBreakMinutes) * time.Minute
+	}
+	return time.Duration(s.WorkMinutes) * time.Minute
+}
+
+func (p *Pomodoro) remaining(now time.Time) time.Duration {
+	switch p.Status {
+	case "active":
+		return p.left()
+	case "paused":
+		return p.PausedRemaining
+	}
+	return p.length(p.phase())
+}
+
+func (p *Pomodoro) today(now time.Time) int {
+	return p.Counts[now.Format("2006-01-02")]
+}
+
+// advance ends the current phase and starts the next one at now. It returns
+// true when the phase that ended was a work session.
+func (p *Pomodoro) advance(now time.Time) bool {
+	worked := p.phase() == "work"
+	if worked {
+		p.Streak++
+		if p.Counts == nil {
+			p.Counts = map[string]int{}
+		}
+		p.Counts[now.Format("2006-01-02")]++
+		p.Phase = "short_break"
+		if p.Streak >= p.settings().LongBreakEvery {
+			p.Phase = "long_break"
+			p.Streak = 0
+		}
+	} else {
+		p.Phase = "work"
+	}
+	p.startPhase(p.Phase, now)
+	return worked
+}
+
+// startPhase starts phase counting down from now
+func (p *Pomodoro) startPhase(phase string, now time.Time) {
+	p.Phase = phase
+	p.Status = "active"
+	p.start(p.length(phase), now)
+	p.TargetTime = now.Round(0).Add(p.Duration)
+	p.PausedRemaining = 0
+}
+
+// counting reports whether a phase is running
+func (p *Pomodoro) counting() bool {
+	return p.Status == "active" && p.Duration > 0
+}
+
+// stop stops the timer at the start of a work session
+func (p *Pomodoro) stop() {
+	p.Phase = "work"
+	p.Status = ""
+	p.TargetTime = time.Time{}
+	p.PausedRemaining = 0
+	p.Countdown = Countdown{}
+}
+
+// catchUpPomodoro counts the wall-clock time lif was closed towards the
+// running phase. Phases saved before they were counted down are converted
+// from their target time.
+func (m *model) catchUpPomodoro(now time.Time) {
+	p := &m.data.Pomodoro
+	if p.Status != "active" {
+		return
+	}
+	if p.Duration == 0 {
+		d := p.length(p.phase())
+		p.start(d, now)
+		p.Elapsed = max(d-p.TargetTime.Sub(now), 0)
+	}
+	// A clock set back while lif was closed counts as no time
+	p.TargetTime = p.count(max(now.Sub(p.CountedAt), 0), now)
+	m.expirePomodoro("while lif was closed")
+}
+
+// expirePomodoro stops a phase that ran out with nobody there to see it end,
+// without counting it. Ticks end a phase within a second, so one that
+// overran by more came to an end while lif was closed or not ticking.
+func (m *model) expirePomodoro(while string) {
+	p := &m.data.Pomodoro
+	if !p.counting() || p.Elapsed-p.Duration <= clockJumpThreshold {
+		return
+	}
+	message := "🍅 The break ended " + while
+	if p.phase() == "work" {
+		message = "🍅 The pomodoro ran out " + while + " and wasn't counted"
+	}
+	p.stop()
+	m.statusMsg = message
+	m.statusColor = "226"
+	m.statusExpiry = time.Now().Add(5 * time.Second)
+	m.saveQuiet()
+}
+
+func (m *model) pomodoroTodo() *RollingTodo {
+	if m.data.Pomodoro.TodoID == 0 {
+		return nil
+	}
+	return todoByID(m.data.RollingTodos, m.data.Pomodoro.TodoID)
+}
+
+// checkPomodoro is called on every tick and moves on to the next phase once
+// the current one is over
+func (m *model) checkPomodoro(now time.Time) {
+	p := &m.data.Pomodoro
+	if !p.counting() || p.left() > 0 {
+		return
+	}
+
+	worked := p.advance(now)
+	next := pomodoroPhaseNames[p.Phase]
+	var message string
+	if worked {
+		name := "Pomodoro"
+		if todo := m.pomodoroTodo(); todo != nil {
+			todo.Pomodoros++
+			name = todo.Task
+		}
+		m.logActivity("completed", "pomodoro", p.TodoID, name)
+		message = fmt.Sprintf("Pomodoro done (%d today). %s: %d minutes", p.today(now), next, int(p.length(p.Phase).Minutes()))
+		m.tables[1].SetRows(m.rollingRows())
+	} else {
+		message = fmt.Sprintf("Break is over. Work: %d minutes", int(p.length(p.Phase).Minutes()))
+	}
+	m.notify(nil, "", Notification{Title: "Pomodoro", Message: message})
+	m.statusMsg = "🍅 " + message
+	m.statusColor = "226"
+	m.statusExpiry = time.Now().Add(5 * time.Second)
+	m.saveQuiet()
+}
+
+func (m *model) togglePomodoro() {
+	p := &m.data.Pomodoro
+	now := time.Now()
+	switch p.Status {
+	case "active":
+		p.PausedRemaining = p.remaining(now)
+		p.Status = "paused"
+	case "paused":
+		if p.Duration == 0 {
+			// Paused before phases were counted down
+			p.Duration = p.length(p.phase())
+		}
+		p.resume(p.PausedRemaining, now)
+		p.TargetTime = now.Round(0).Add(p.PausedRemaining)
+		p.PausedRemaining = 0
+		p.Status = "active"
+	default:
+		p.startPhase(p.phase(), now)
+	}
+	m.saveQuiet()
+}
+
+// resetPomodoro stops the timer and goes back to the start of a work session.
+// The day's count is kept.
+func (m *model) resetPomodoro() {
+	p := &m.data.Pomodoro
+	p.stop()
+	p.Streak = 0
+	m.saveQuiet()
+}
+
+// skipPomodoro moves on to the next phase without counting the current one
+func (m *model) skipPomodoro() {
+	p := &m.data.Pomodoro
+	next := "work"
+	if p.phase() == "work" {
+		next = "short_break"
+	}
+	p.startPhase(next, time.Now())
+	m.saveQuiet()
+}
+
+// openPomodoro shows the timer. When opened from the Rolling tab the selected
+// todo can be linked with l.
+func (m *model) openPomodoro() {
+	m.pomodoroOpen = true
+	m.pomodoroCandidate = 0
+	if m.activeTab == 3 {
+		if idx := m.selectedIndex(1); idx != -1 {
+			m.pomodoroCandidate = m.data.RollingTodos[idx].ID
+		}
+	}
+}
+
+func (m *model) toggleTodoLink() {
+	p := &m.data.Pomodoro
+	if m.pomodoroCandidate == 0 {
+		if p.TodoID != 0 {
+			p.TodoID = 0
+			m.saveQuiet()
+		}
+		return
+	}
+	if p.TodoID == m.pomodoroCandidate {
+		p.TodoID = 0
+	} else {
+		p.TodoID = m.pomodoroCandidate
+	}
+	m.saveQuiet()
+}
+
+func (m *model) startPomodoroConfig() {
+	s := m.data.Pomodoro.settings()
+	values := []int{s.WorkMinutes, s.ShortBreakMinutes, s.LongBreakMinutes, s.LongBreakEvery}
+	m.pomodoroConfig = true
+	m.pomodoroField = 0
+	m.pomodoroInputs = make([]textinput.Model, len(values))
+	for i, value := range values {
+		m.pomodoroInputs[i] = textinput.New()
+		m.pomodoroInputs[i].CharLimit = 3
+		m.pomodoroInputs[i].SetValue(strconv.Itoa(value))
+	}
+	m.pomodoroInputs[0].Focus()
+}
+
+func (m *model) savePomodoroConfig() error {
+	values := make([]int, len(m.pomodoroInputs))
+	for i, input := range m.pomodoroInputs {
+		value, err := strconv.Atoi(strings.TrimSpace(input.Value()))
+		if err != nil || value <= 0 {
+			return fmt.Errorf("%q is not a positive number", input.Value())
+		}
+		values[i] = value
+	}
+	m.data.Pomodoro.Settings = PomodoroSettings{
+		WorkMinutes:       values[0],
+		ShortBreakMinutes: values[1],
+		LongBreakMinutes:  values[2],
+		LongBreakEvery:    values[3],
+	}
+	m.pomodoroConfig = false
+	m.saveQuiet()
+	return nil
+}
+
+func (m model) handlePomodoroKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.pomodoroConfig {
+		switch msg.String() {
+		case "esc":
+			m.pomodoroConfig = false
+			return m, nil
+		case "enter":
+			if err := m.savePomodoroConfig(); err != nil {
+				return m, showStatus("❌ "+err.Error(), "196")
+			}
+			return m, showStatus("🍅 Pomodoro lengths saved", "82")
+		case "tab", "down":
+			m.pomodoroInputs[m.pomodoroField].Blur()
+			m.pomodoroField = (m.pomodoroField + 1) % len(m.pomodoroInputs)
+			m.pomodoroInputs[m.pomodoroField].Focus()
+			return m, nil
+		case "shift+tab", "up":
+			m.pomodoroInputs[m.pomodoroField].Blur()
+			m.pomodoroField = (m.pomodoroField + len(m.pomodoroInputs) - 1) % len(m.pomodoroInputs)
+			m.pomodoroInputs[m.pomodoroField].Focus()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.pomodoroInputs[m.pomodoroField], cmd = m.pomodoroInputs[m.pomodoroField].Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q", "P":
+		m.pomodoroOpen = false
+	case " ", "s", "enter":
+		m.togglePomodoro()
+	case "r":
+		m.resetPomodoro()
+	case "n":
+		m.skipPomodoro()
+	case "l":
+		m.toggleTodoLink()
+	case "c":
+		m.startPomodoroConfig()
+	}
+	return m, nil
+}
+
+// Three-line block digits for the big timer
+var bigGlyphs = map[rune][3]string{
+	'0': {"█▀█", "█ █", "▀▀▀"},
+	'1': {"▀█ ", " █ ", "▀▀▀"},
+	'2': {"▀▀█", "█▀▀", "▀▀▀"},
+	'3': {"▀▀█", " ▀█", "▀▀▀"},
+	'4': {"█ █", "▀▀█", "  ▀"},
+	'5': {"█▀▀", "▀▀█", "▀▀▀"},
+	'6': {"█▀▀", "█▀█", "▀▀▀"},
+	'7': {"▀▀█", "  █", "  ▀"},
+	'8': {"█▀█", "█▀█", "▀▀▀"},
+	'9': {"█▀█", "▀▀█", "▀▀▀"},
+	':': {"▄", " ", "▀"},
+}
+
+func bigClock(text string) string {
+	rows := [3][]string{}
+	for _, r := range text {
+		glyph, ok := bigGlyphs[r]
+		if !ok {
+			continue
+		}
+		for i := range rows {
+			rows[i] = append(rows[i], glyph[i])
+		}
+	}
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = strings.Join(row, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatMinutes renders a duration as mm:ss, or h:mm:ss for an hour or more
+func formatMinutes(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d >= time.Hour {
+		return formatClock(d)
+	}
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// pomodoroHeader shows the running pomodoro next to the app title
+func (m *model) pomodoroHeader() string {
+	p := &m.data.Pomodoro
+	if p.Status == "" {
+		return ""
+	}
+	style := pomodoroBreakStyle
+	if p.phase() == "work" {
+		style = pomodoroWorkStyle
+	}
+	text := fmt.Sprintf("🍅 %s %s", pomodoroPhaseNames[p.phase()], formatMinutes(p.remaining(time.Now())))
+	if p.Status == "paused" {
+		text += " ⏸"
+	}
+	return style.Render(text)
+}
+
+func (m model) pomodoroView() string {
+	p := &m.data.Pomodoro
+	now := time.Now()
+	header := headerStyle.Render(fmt.Sprintf("🍅 Pomodoro — %d today", p.today(now)))
+
+	if m.pomodoroConfig {
+		labels := []string{"Work (minutes):", "Short break (minutes):", "Long break (minutes):", "Long break every (pomodoros):"}
+		lines := []string{}
+		for i, input := range m.pomodoroInputs {
+			lines = append(lines, fmt.Sprintf("%-30s %s", labels[i], input.View()))
+		}
+		footer := keyStyle.Render("tab") + ": " + actionStyle.Render("next field") + bulletStyle.Render(" • ") +
+			keyStyle.Render("enter") + ": " + actionStyle.Render("save") + bulletStyle.Render(" • ") +
+			keyStyle.Render("esc") + ": " + actionStyle.Render("cancel")
+		return lipgloss.JoinVertical(lipgloss.Top, header, "", strings.Join(lines, "\n"), "", footer)
+	}
+
+	style := pomodoroBreakStyle
+	if p.phase() == "work" {
+		style = pomodoroWorkStyle
+	}
+	state := "stopped"
+	if p.Status == "active" {
+		state = "running"
+	} else if p.Status == "paused" {
+		state = "paused"
+	}
+	s := p.settings()
+	phase := fmt.Sprintf("%s (%s) • %d/%d until long break", pomodoroPhaseNames[p.phase()], state, p.Streak, s.LongBreakEvery)
+
+	link := bulletStyle.Render("Not linked to a todo")
+	if todo := m.pomodoroTodo(); todo != nil {
+		link = fmt.Sprintf("Working on #%d %s (🍅 %d)", todo.ID, todo.Task, todo.Pomodoros)
+	}
+
+	// Count for each of the last 7 days, oldest first
+	days := []string{}
+	y, mo, d := now.Date()
+	for i := 6; i >= 0; i-- {
+		day := time.Date(y, mo, d-i, 0, 0, 0, 0, now.Location())
+		days = append(days, fmt.Sprintf("%s %d", day.Format("Mon"), p.Counts[day.Format("2006-01-02")]))
+	}
+
+	commands := []string{
+		keyStyle.Render("space") + ": " + actionStyle.Render("start/pause"),
+		keyStyle.Render("n") + ": " + actionStyle.Render("skip phase"),
+		keyStyle.Render("r") + ": " + actionStyle.Render("reset"),
+		keyStyle.Render("c") + ": " + actionStyle.Render(fmt.Sprintf("lengths %d/%d/%d", s.WorkMinutes, s.ShortBreakMinutes, s.LongBreakMinutes)),
+	}
+	if m.pomodoroCandidate != 0 {
+		if todo := todoByID(m.data.RollingTodos, m.pomodoroCandidate); todo != nil {
+			action := fmt.Sprintf("link #%d %s", todo.ID, todo.Task)
+			if p.TodoID == todo.ID {
+				action = "unlink todo"
+			}
+			commands = append(commands, keyStyle.Render("l")+": "+actionStyle.Render(action))
+		}
+	} else if p.TodoID != 0 {
+		commands = append(commands, keyStyle.Render("l")+": "+actionStyle.Render("unlink todo"))
+	}
+	commands = append(commands, keyStyle.Render("esc")+": "+actionStyle.Render("close"))
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		header,
+		"",
+		style.Render(phase),
+		"",
+		style.Render(bigClock(formatMinutes(p.remaining(now)))),
+		"",
+		link,
+		bulletStyle.Render(strings.Join(days, " • ")),
+		"",
+		strings.Join(commands, bulletStyle.Render(" • ")),
+	)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// tempConfigDir points the config directory at an empty one that saveData
+// can write to
+func tempConfigDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "lif"), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCatchUpPomodoroDoesNotCountClosedSessions(t *testing.T) {
+	tempConfigDir(t)
+
+	now := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	m := model{}
+	m.data.Pomodoro.startPhase("work", now)
+
+	// Closed ten minutes into the session and opened again an hour later
+	m.data.Pomodoro.count(10*time.Minute, now.Add(10*time.Minute))
+	m.catchUpPomodoro(now.Add(70 * time.Minute))
+
+	p := m.data.Pomodoro
+	if p.Status != "" || p.phase() != "work" {
+		t.Errorf("status %q phase %q, want a stopped work session", p.Status, p.Phase)
+	}
+	if p.today(now) != 0 || p.Streak != 0 {
+		t.Errorf("counted %d today, streak %d, want nothing counted", p.today(now), p.Streak)
+	}
+}
+
+func TestCatchUpPomodoroKeepsRunning(t *testing.T) {
+	now := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	m := model{}
+	m.data.Pomodoro.startPhase("work", now)
+	m.catchUpPomodoro(now.Add(10 * time.Minute))
+
+	p := m.data.Pomodoro
+	if p.Status != "active" || p.remaining(now) != 15*time.Minute {
+		t.Errorf("status %q remaining %s, want active with 15m left", p.Status, p.remaining(now))
+	}
+
+	// Saved before phases were counted down
+	m.data.Pomodoro = Pomodoro{Phase: "short_break", Status: "active", TargetTime: now.Add(3 * time.Minute)}
+	m.catchUpPomodoro(now)
+	if got := m.data.Pomodoro.remaining(now); got != 3*time.Minute {
+		t.Errorf("converted phase has %s left, want 3m", got)
+	}
+}
+
+func TestAdvanceCountdownsExpiresPomodoroAfterSuspend(t *testing.T) {
+	tempConfigDir(t)
+
+	last := time.Now()
+	m := model{}
+	m.data.Pomodoro.startPhase("work", last)
+
+	// An hour passed between two ticks, as it does across a suspend
+	now := last.Add(time.Second).Round(0).Add(time.Hour)
+	m.advanceCountdowns(tickClock{Time: last, Boot: time.Minute}, tickClock{Time: now, Boot: time.Hour + time.Minute + time.Second})
+	if p := m.data.Pomodoro; p.Status != "" || p.today(now) != 0 {
+		t.Errorf("status %q, %d counted after a suspend, want stopped and uncounted", p.Status, p.today(now))
+	}
+}
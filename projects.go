@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Width of the Project column, needed to fit colored project names
+const projectNameWidth = 20
+
+func nextProjectID(projects []Project) int {
+	maxID := 0
+	for _, project := range projects {
+		if project.ID > maxID {
+			maxID = project.ID
+		}
+	}
+	return maxID + 1
+}
+
+func normalizeProjectStatus(status string) string {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "on-hold", "on hold", "onhold", "hold", "h", "paused":
+		return "on-hold"
+	case "done", "d", "complete", "completed", "finished":
+		return "done"
+	default:
+		return "active"
+	}
+}
+
+func projectByID(projects []Project, id int) *Project {
+	if id == 0 {
+		return nil
+	}
+	for i := range projects {
+		if projects[i].ID == id {
+			return &projects[i]
+		}
+	}
+	return nil
+}
+
+func projectName(projects []Project, id int) string {
+	if project := projectByID(projects, id); project != nil {
+		return project.Name
+	}
+	return ""
+}
+
+func projectArchived(projects []Project, id int) bool {
+	project := projectByID(projects, id)
+	return project != nil && project.Archived
+}
+
+// findProjectID resolves the Project field of the todo form; an empty name
+// means no project.
+func findProjectID(projects []Project, name string) (int, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, nil
+	}
+	for _, project := range projects {
+		if strings.EqualFold(project.Name, name) {
+			return project.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("no project named %q", name)
+}
+
+// projectProgress counts the done and total todos assigned to a project
+func projectProgress(todos []RollingTodo, id int) (done, total int) {
+	for _, todo := range todos {
+		if todo.ProjectID != id {
+			continue
+		}
+		total++
+		if todo.Done {
+			done++
+		}
+	}
+	return done, total
+}
+
+func progressBar(done, total, width int) string {
+	if total == 0 {
+		return strings.Repeat("░", width)
+	}
+	filled := done * width / total
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+func (m *model) projectRows() []table.Row {
+	rows := []table.Row{}
+	m.rowIndex[4] = nil
+	for _, i := range sortItems(&m.data, 6, m.sortPref(6)) {
+		project := m.data.Projects[i]
+		if project.Archived && !m.showArchived {
+			continue
+		}
+		m.rowIndex[4] = append(m.rowIndex[4], i)
+
+		name := m.markCell(6, i, project.Name)
+		if project.Color != "" {
+			name = fitStyled(lipgloss.NewStyle().Foreground(lipgloss.Color(project.Color)).Bold(true), name, projectNameWidth)
+		}
+
+		status := project.Status
+		if project.Archived {
+			status = "archived"
+		}
+
+		done, total := projectProgress(m.data.RollingTodos, project.ID)
+		progress := fmt.Sprintf("%d/%d %s", done, total, progressBar(done, total, 6))
+
+		rows = append(rows, table.Row{
+			name,
+			status,
+			project.Due,
+			progress,
+			project.Description,
+		})
+	}
+	return rows
+}
+
+// toggleProjectArchived archives the selected project, which also hides its
+// todos from the Rolling tab, or restores an archived one.
+func (m *model) toggleProjectArchived() {
+	idx := m.selectedIndex(4)
+	if idx == -1 {
+		return
+	}
+	project := &m.data.Projects[idx]
+	project.Archived = !project.Archived
+
+	if project.Archived {
+		m.logItem("archived", 6, idx)
+		m.statusMsg = fmt.Sprintf("📦 Archived: %s", project.Name)
+	} else {
+		m.logItem("unarchived", 6, idx)
+		m.statusMsg = fmt.Sprintf("📂 Restored: %s", project.Name)
+	}
+	m.statusColor = "86"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+
+	m.tables[4].SetRows(m.projectRows())
+	m.tables[1].SetRows(m.rollingRows())
+	m.save("archive")
+}
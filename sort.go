@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Per-tab sort preferences and manual ordering
+
+// Keys of AppData.SortPrefs, by tab
+var sortTabNames = map[int]string{
+	2: "dailies",
+	3: "rolling",
+	4: "reminders",
+	5: "glossary",
+	6: "projects",
+}
+
+// Column keys offered in the sort menu, by tab
+var sortKeys = map[int][]string{
+	2: {"task", "priority", "category", "deadline", "status"},
+	3: {"id", "task", "priority", "category", "project", "deadline", "progress"},
+	4: {"reminder", "note", "time", "status"},
+	5: {"lang", "command", "usage", "example", "meaning"},
+	6: {"name", "status", "due", "progress"},
+}
+
+func (m *model) sortPref(tab int) SortPref {
+	return m.data.SortPrefs[sortTabNames[tab]]
+}
+
+// sortOptions lists the sort menu entries of a tab: default, each column and
+// manual
+func sortOptions(tab int) []string {
+	options := []string{""}
+	options = append(options, sortKeys[tab]...)
+	return append(options, "manual")
+}
+
+func sortLabel(pref SortPref) string {
+	switch pref.Key {
+	case "":
+		return "default"
+	case "manual":
+		return "manual"
+	}
+	if pref.Desc {
+		return pref.Key + " ▼"
+	}
+	return pref.Key + " ▲"
+}
+
+func (m *model) refreshTab(tab int) {
+	switch tab {
+	case 2:
+		m.tables[0].SetRows(m.dailyRows())
+	case 3:
+		m.tables[1].SetRows(m.rollingRows())
+	case 4:
+		m.tables[2].SetRows(m.reminderRows())
+	case 5:
+		m.tables[3].SetRows(m.glossaryRows())
+	case 6:
+		m.tables[4].SetRows(m.projectRows())
+	}
+}
+
+func (m *model) setSortPref(tab int, pref SortPref) {
+	if m.data.SortPrefs == nil {
+		m.data.SortPrefs = map[string]SortPref{}
+	}
+	if pref.Key == "" {
+		delete(m.data.SortPrefs, sortTabNames[tab])
+	} else {
+		m.data.SortPrefs[sortTabNames[tab]] = pref
+	}
+	m.refreshTab(tab)
+}
+
+func (m *model) startSortMenu() {
+	m.sortMenu = true
+	m.sortCursor = 0
+	current := m.sortPref(m.activeTab).Key
+	for i, key := range sortOptions(m.activeTab) {
+		if key == current {
+			m.sortCursor = i
+		}
+	}
+}
+
+func (m model) handleSortMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	options := sortOptions(m.activeTab)
+	switch msg.String() {
+	case "esc", "S":
+		m.sortMenu = false
+	case "left", "h", "up", "k":
+		if m.sortCursor > 0 {
+			m.sortCursor--
+		}
+	case "right", "l", "down", "j":
+		if m.sortCursor < len(options)-1 {
+			m.sortCursor++
+		}
+	case "enter", " ":
+		m.sortMenu = false
+		pref := m.sortPref(m.activeTab)
+		key := options[m.sortCursor]
+		// Picking the active column again flips the direction
+		if key == pref.Key && key != "" && key != "manual" {
+			pref.Desc = !pref.Desc
+		} else {
+			pref = SortPref{Key: key}
+		}
+		m.setSortPref(m.activeTab, pref)
+		m.saveQuiet()
+		return m, showStatus("Sorted by "+sortLabel(pref), "86")
+	}
+	return m, nil
+}
+
+func (m model) sortMenuView() string {
+	pref := m.sortPref(m.activeTab)
+	parts := []string{}
+	for i, key := range sortOptions(m.activeTab) {
+		label := key
+		if key == "" || key == pref.Key {
+			label = sortLabel(SortPref{Key: key, Desc: pref.Desc})
+		}
+		if i == m.sortCursor {
+			label = activeTabStyle.Render(label)
+		} else {
+			label = actionStyle.Render(label)
+		}
+		parts = append(parts, label)
+	}
+	return keyStyle.Render("Sort by: ") + strings.Join(parts, " ") + bulletStyle.Render("  (←→ choose, enter select, esc cancel)")
+}
+
+// itemOrder returns the manual position field of an item on a tab
+func (m *model) itemOrder(tab, idx int) *int {
+	switch tab {
+	case 2:
+		return &m.data.Dailies[idx].Order
+	case 3:
+		return &m.data.RollingTodos[idx].Order
+	case 4:
+		return &m.data.Reminders[idx].Order
+	case 5:
+		return &m.data.Glossary[idx].Order
+	case 6:
+		return &m.data.Projects[idx].Order
+	}
+	return nil
+}
+
+// moveSelected moves the selected row up (-1) or down (1). The tab switches to
+// manual order, seeded from whatever order is currently shown, so the first
+// move doesn't shuffle the list. On a subtask row the subtask moves instead.
+func (m *model) moveSelected(delta int) {
+	tab := m.activeTab
+	tableIdx := tab - 2
+	cursor := m.tables[tableIdx].Cursor()
+	idx := m.selectedIndex(tableIdx)
+	if idx == -1 {
+		return
+	}
+
+	if tab == 3 && m.rowSubtask[cursor] != -1 {
+		m.moveSubtask(idx, m.rowSubtask[cursor], delta)
+		return
+	}
+
+	// Find the neighbouring item, skipping subtask rows
+	neighbour := -1
+	for row := cursor + delta; row >= 0 && row < len(m.rowIndex[tableIdx]); row += delta {
+		if m.rowIndex[tableIdx][row] == -1 || (tab == 3 && m.rowSubtask[row] != -1) {
+			continue
+		}
+		neighbour = m.rowIndex[tableIdx][row]
+		break
+	}
+	if neighbour == -1 {
+		return
+	}
+
+	if m.sortPref(tab).Key != "manual" {
+		for rank, i := range sortItems(&m.data, tab, m.sortPref(tab)) {
+			*m.itemOrder(tab, i) = rank + 1
+		}
+		m.setSortPref(tab, SortPref{Key: "manual"})
+		m.statusMsg = "Switched to manual order"
+		m.statusColor = "86"
+		m.statusExpiry = time.Now().Add(2 * time.Second)
+	}
+	a, b := m.itemOrder(tab, idx), m.itemOrder(tab, neighbour)
+	if *a == 0 || *b == 0 {
+		// Items added since the last reorder have no position yet
+		for rank, i := range sortItems(&m.data, tab, m.sortPref(tab)) {
+			*m.itemOrder(tab, i) = rank + 1
+		}
+	}
+	*a, *b = *b, *a
+
+	m.refreshTab(tab)
+	for row, i := range m.rowIndex[tableIdx] {
+		if i == idx && (tab != 3 || m.rowSubtask[row] == -1) {
+			m.tables[tableIdx].SetCursor(row)
+			break
+		}
+	}
+	if tab == 3 && m.boardMode {
+		m.focusBoardCard(m.data.RollingTodos[idx].ID)
+	}
+	m.save("reorder")
+}
+
+func (m *model) moveSubtask(todoIdx, sub, delta int) {
+	subtasks := m.data.RollingTodos[todoIdx].Subtasks
+	target := sub + delta
+	if target < 0 || target >= len(subtasks) {
+		return
+	}
+	subtasks[sub], subtasks[target] = subtasks[target], subtasks[sub]
+
+	m.tables[1].SetRows(m.rollingRows())
+	for row, i := range m.rowIndex[1] {
+		if i == todoIdx && m.rowSubtask[row] == target {
+			m.tables[1].SetCursor(row)
+			break
+		}
+	}
+	m.save("subtask reorder")
+}
+
+// sortLine describes a non-default sort order above the table
+func (m *model) sortLine() string {
+	pref := m.sortPref(m.activeTab)
+	if pref.Key == "" {
+		return ""
+	}
+	return bulletStyle.Render(fmt.Sprintf("Sort: %s", sortLabel(pref)))
+}
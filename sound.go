@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Notification sounds. A sound is a name looked up in ~/.config/lif/sounds, a
+// path, or "none"; the default is the embedded notification sound, written to
+// the cache dir so external players can open it.
+
+//go:embed assets/notification.mp3 assets/notification.wav
+var embeddedSounds embed.FS
+
+type SoundSettings struct {
+	Mute bool `json:"mute,omitempty"`
+	// 1-100; 100 when unset
+	Volume int `json:"volume,omitempty"`
+	// Sound used when a reminder doesn't pick one
+	Default string `json:"default,omitempty"`
+	// Player command; {file} and {volume} (0-100) are replaced, the file is
+	// appended when {file} is missing
+	Player []string `json:"player,omitempty"`
+}
+
+var soundExtensions = []string{".mp3", ".wav", ".ogg", ".oga", ".flac"}
+
+func (s SoundSettings) volume() int {
+	if s.Volume <= 0 || s.Volume > 100 {
+		return 100
+	}
+	return s.Volume
+}
+
+func soundsDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "lif", "sounds"), nil
+}
+
+// resolveSound finds the file for a sound name. An empty path with no error
+// means the embedded default.
+func resolveSound(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	if strings.ContainsRune(name, filepath.Separator) || strings.ContainsRune(name, '/') {
+		path := name
+		if strings.HasPrefix(path, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, path[2:])
+			}
+		}
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("sound file %s not found", name)
+		}
+		return path, nil
+	}
+
+	dir, err := soundsDir()
+	if err != nil {
+		return "", err
+	}
+	candidates := []string{filepath.Join(dir, name)}
+	for _, ext := range soundExtensions {
+		candidates = append(candidates, filepath.Join(dir, name+ext))
+	}
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no sound %q in %s", name, dir)
+}
+
+// validateSound checks a sound choice from the edit form
+func validateSound(name string) error {
+	if name == "" || name == "none" {
+		return nil
+	}
+	_, err := resolveSound(name)
+	return err
+}
+
+// embeddedSoundFile writes the embedded default sound to the cache dir once
+// and returns its path. Windows' SoundPlayer only plays WAV.
+func embeddedSoundFile(goos string) (string, error) {
+	name := "notification.mp3"
+	if goos == "windows" {
+		name = "notification.wav"
+	}
+	data, err := embeddedSounds.ReadFile("assets/" + name)
+	if err != nil {
+		return "", err
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(cacheDir, "lif", name)
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return path, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, data, 0644)
+}
+
+// playerCommand builds the command that plays file at volume (0-100). With
+// no custom player it picks the first installed one for goos; ok is false
+// when none is available.
+func playerCommand(goos string, wsl bool, player []string, file string, volume int, lookPath func(string) (string, error)) (commandSpec, bool) {
+	if len(player) > 0 {
+		args := []string{}
+		hasFile := false
+		for _, arg := range player[1:] {
+			if strings.Contains(arg, "{file}") {
+				hasFile = true
+			}
+			arg = strings.ReplaceAll(arg, "{file}", file)
+			arg = strings.ReplaceAll(arg, "{volume}", strconv.Itoa(volume))
+			args = append(args, arg)
+		}
+		if !hasFile {
+			args = append(args, file)
+		}
+		return commandSpec{Name: player[0], Args: args}, true
+	}
+
+	fraction := strconv.FormatFloat(float64(volume)/100, 'f', 2, 64)
+	switch {
+	case goos == "linux" || wsl:
+		players := []commandSpec{
+			{Name: "mpv", Args: []string{"--no-video", "--really-quiet", "--audio-buffer=1.0", "--volume=" + strconv.Itoa(volume), "--", file}},
+			{Name: "vlc", Args: []string{"--intf", "dummy", "--play-and-exit", "--gain", fraction, file}},
+			{Name: "mplayer", Args: []string{"-really-quiet", "-volume", strconv.Itoa(volume), file}},
+			{Name: "ffplay", Args: []string{"-nodisp", "-autoexit", "-v", "quiet", "-volume", strconv.Itoa(volume), file}},
+			{Name: "paplay", Args: []string{"--volume=" + strconv.Itoa(volume*65536/100), file}},
+		}
+		for _, spec := range players {
+			if _, err := lookPath(spec.Name); err == nil {
+				return spec, true
+			}
+		}
+	case goos == "darwin":
+		return commandSpec{Name: "afplay", Args: []string{"-v", fraction, file}}, true
+	case goos == "windows":
+		// The path is passed in the environment rather than spliced into
+		// the script; SoundPlayer has no volume control
+		return commandSpec{
+			Name: "powershell",
+			Args: []string{"-NoProfile", "-NonInteractive", "-Command", "(New-Object Media.SoundPlayer $env:LIF_SOUND).PlaySync()"},
+			Env:  []string{"LIF_SOUND=" + file},
+		}, true
+	}
+	return commandSpec{}, false
+}
+
+// beep is the fallback when there is no sound file or player
+func (m *model) beep() {
+	if isWSL() {
+		go exec.Command("powershell.exe", "-Command", "[console]::beep(800,200)").Run()
+		return
+	}
+	m.ringBell()
+}
+
+// playSound plays a sound by name ("" for the default, "none" for silence)
+// unless sounds are muted. Quiet hours are Do Not Disturb, which holds the
+// notification and its sound back before it gets here. It returns false
+// when there is no sound file or player, for the caller to beep instead.
+func playSound(settings SoundSettings, name string) bool {
+	if settings.Mute || name == "none" {
+		return true
+	}
+	if name == "" {
+		name = settings.Default
+	}
+	if name == "none" {
+		return true
+	}
+
+	file, err := resolveSound(name)
+	if err == nil && file == "" {
+		file, err = embeddedSoundFile(runtime.GOOS)
+	}
+	if err != nil {
+		return false
+	}
+	spec, ok := playerCommand(runtime.GOOS, isWSL(), settings.Player, file, settings.volume(), exec.LookPath)
+	if !ok {
+		return false
+	}
+	go spec.command().Run()
+	return true
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Stopwatch tab. The running stopwatch only stores when it was started, so it
+// keeps counting while lif is closed.
+
+type Stopwatch struct {
+	StartedAt time.Time `json:"started_at"` // zero when stopped
+	// Time counted before StartedAt
+	Elapsed time.Duration `json:"elapsed,omitempty"`
+	Laps    []Lap         `json:"laps,omitempty"`
+}
+
+type Lap struct {
+	Label      string        `json:"label,omitempty"`
+	Split      time.Duration `json:"split"` // since the previous lap
+	Total      time.Duration `json:"total"`
+	RecordedAt time.Time     `json:"recorded_at"`
+}
+
+var stopwatchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+
+func (s *Stopwatch) running() bool {
+	return !s.StartedAt.IsZero()
+}
+
+func (s *Stopwatch) elapsed(now time.Time) time.Duration {
+	if s.running() {
+		return s.Elapsed + now.Sub(s.StartedAt)
+	}
+	return s.Elapsed
+}
+
+// formatLap renders a lap time with tenths: mm:ss.t or h:mm:ss.t
+func formatLap(d time.Duration) string {
+	d = d.Truncate(100 * time.Millisecond)
+	tenths := int(d.Milliseconds()/100) % 10
+	if d >= time.Hour {
+		return fmt.Sprintf("%s.%d", formatClock(d), tenths)
+	}
+	return fmt.Sprintf("%02d:%02d.%d", int(d.Minutes()), int(d.Seconds())%60, tenths)
+}
+
+func (m *model) toggleStopwatch() {
+	s := &m.data.Stopwatch
+	now := time.Now()
+	if s.running() {
+		s.Elapsed = s.elapsed(now)
+		s.StartedAt = time.Time{}
+	} else {
+		s.StartedAt = now
+	}
+	m.saveQuiet()
+}
+
+func (m *model) recordLap() {
+	s := &m.data.Stopwatch
+	if !s.running() {
+		m.statusMsg = "Start the stopwatch to record laps"
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(2 * time.Second)
+		return
+	}
+	now := time.Now()
+	total := s.elapsed(now)
+	split := total
+	if len(s.Laps) > 0 {
+		split = total - s.Laps[len(s.Laps)-1].Total
+	}
+	s.Laps = append(s.Laps, Lap{Split: split, Total: total, RecordedAt: now})
+	m.lapCursor = 0
+	m.save("lap")
+}
+
+func (m *model) resetStopwatch() {
+	m.data.Stopwatch = Stopwatch{}
+	m.lapCursor = 0
+	m.save("stopwatch reset")
+}
+
+// lapAt maps a cursor position to a lap index; the newest lap comes first
+func (m *model) lapAt(cursor int) int {
+	return len(m.data.Stopwatch.Laps) - 1 - cursor
+}
+
+func (m *model) deleteLap() {
+	laps := m.data.Stopwatch.Laps
+	if len(laps) == 0 {
+		return
+	}
+	idx := m.lapAt(m.lapCursor)
+	// The next lap's split now runs from the lap before the removed one
+	if idx+1 < len(laps) {
+		laps[idx+1].Split += laps[idx].Split
+	}
+	m.data.Stopwatch.Laps = append(laps[:idx], laps[idx+1:]...)
+	if m.lapCursor >= len(m.data.Stopwatch.Laps) && m.lapCursor > 0 {
+		m.lapCursor--
+	}
+	m.save(fmt.Sprintf("delete of lap %d", idx+1))
+}
+
+func (m *model) startLapLabel() {
+	if len(m.data.Stopwatch.Laps) == 0 {
+		return
+	}
+	m.lapLabeling = true
+	m.lapInput = textinput.New()
+	m.lapInput.Prompt = fmt.Sprintf("Label for lap %d: ", m.lapAt(m.lapCursor)+1)
+	m.lapInput.SetValue(m.data.Stopwatch.Laps[m.lapAt(m.lapCursor)].Label)
+	m.lapInput.Focus()
+}
+
+func (m model) handleLapLabelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.lapLabeling = false
+		return m, nil
+	case "enter":
+		m.lapLabeling = false
+		m.data.Stopwatch.Laps[m.lapAt(m.lapCursor)].Label = normalizeText(m.lapInput.Value())
+		m.save("lap label")
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.lapInput, cmd = m.lapInput.Update(msg)
+	return m, cmd
+}
+
+// exportLaps writes the laps as CSV, oldest first, with times in seconds
+func exportLaps(w io.Writer, laps []Lap) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"lap", "label", "split", "total", "recorded_at"})
+	for i, lap := range laps {
+		writer.Write([]string{
+			strconv.Itoa(i + 1),
+			lap.Label,
+			strconv.FormatFloat(lap.Split.Seconds(), 'f', 1, 64),
+			strconv.FormatFloat(lap.Total.Seconds(), 'f', 1, 64),
+			lap.RecordedAt.Format(time.RFC3339),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// exportLapsToHome writes the laps to ~/lif-laps-DATE.csv
+func exportLapsToHome(laps []Lap) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(home, fmt.Sprintf("lif-laps-%s.csv", time.Now().Format("2006-01-02")))
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return path, exportLaps(file, laps)
+}
+
+// handleStopwatchKey handles the keys of the Stopwatch tab and reports
+// whether the key was used
+func (m *model) handleStopwatchKey(key string) bool {
+	switch key {
+	case " ", "s":
+		m.toggleStopwatch()
+	case "l", "enter":
+		m.recordLap()
+	case "r":
+		m.resetStopwatch()
+	case "e":
+		m.startLapLabel()
+	case "d", "delete":
+		m.deleteLap()
+	case "x":
+		if len(m.data.Stopwatch.Laps) == 0 {
+			break
+		}
+		path, err := exportLapsToHome(m.data.Stopwatch.Laps)
+		if err != nil {
+			m.statusMsg = "❌ " + err.Error()
+			m.statusColor = "196"
+		} else {
+			m.statusMsg = "📤 Exported laps to " + path
+			m.statusColor = "82"
+		}
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+	case "up", "k":
+		if m.lapCursor > 0 {
+			m.lapCursor--
+		}
+	case "down", "j":
+		if m.lapCursor < len(m.data.Stopwatch.Laps)-1 {
+			m.lapCursor++
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// stopwatchHeader shows the running stopwatch next to the app title
+func (m *model) stopwatchHeader() string {
+	if !m.data.Stopwatch.running() || m.activeTab == 7 {
+		return ""
+	}
+	return stopwatchStyle.Render("⏲ " + formatClock(m.data.Stopwatch.elapsed(time.Now())))
+}
+
+func (m model) stopwatchView() string {
+	s := &m.data.Stopwatch
+	now := time.Now()
+	state := "stopped"
+	if s.running() {
+		state = "running"
+	}
+
+	elapsed := s.elapsed(now)
+	lines := []string{
+		"",
+		stopwatchStyle.Render(bigClock(formatMinutes(elapsed))),
+		bulletStyle.Render(fmt.Sprintf("%s • %s", formatLap(elapsed), state)),
+		"",
+	}
+
+	if len(s.Laps) == 0 {
+		lines = append(lines, bulletStyle.Render("No laps yet"))
+	}
+	// Show a page of laps around the cursor, newest first
+	visible := len(s.Laps)
+	if m.height > 0 && visible > m.height-18 {
+		visible = m.height - 18
+		if visible < 3 {
+			visible = 3
+		}
+	}
+	start := 0
+	if m.lapCursor >= visible {
+		start = m.lapCursor - visible + 1
+	}
+	for i := start; i < start+visible && i < len(s.Laps); i++ {
+		idx := m.lapAt(i)
+		lap := s.Laps[idx]
+		line := fmt.Sprintf("Lap %-3d %-20s +%s  %s", idx+1, lap.Label, formatLap(lap.Split), formatLap(lap.Total))
+		if i == m.lapCursor {
+			line = activeTabStyle.Render(">") + " " + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	if m.lapLabeling {
+		lines = append(lines, "", "> "+m.lapInput.View())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m model) stopwatchCommands() []string {
+	return []string{
+		keyStyle.Render("space") + ": " + actionStyle.Render("start/stop"),
+		keyStyle.Render("l") + ": " + actionStyle.Render("lap"),
+		keyStyle.Render("e") + ": " + actionStyle.Render("label lap"),
+		keyStyle.Render("d") + ": " + actionStyle.Render("delete lap"),
+		keyStyle.Render("r") + ": " + actionStyle.Render("reset"),
+		keyStyle.Render("x") + ": " + actionStyle.Render("export laps"),
+	}
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// nextTodoID returns an ID that is not used by any rolling todo, so IDs stay
+// stable after deletions.
+func nextTodoID(todos []RollingTodo) int {
+	maxID := 0
+	for _, todo := range todos {
+		if todo.ID > maxID {
+			maxID = todo.ID
+		}
+	}
+	return maxID + 1
+}
+
+// uniqueTodoIDs renumbers todos whose ID is missing or already taken. Older
+// versions numbered new todos by count, which repeats IDs after a delete,
+// and dependencies, the board and the pomodoro find their todo by ID. The
+// first todo with an ID keeps it.
+func uniqueTodoIDs(todos []RollingTodo) {
+	seen := map[int]bool{}
+	for i := range todos {
+		if todos[i].ID <= 0 || seen[todos[i].ID] {
+			todos[i].ID = nextTodoID(todos)
+		}
+		seen[todos[i].ID] = true
+	}
+}
+
+func nextSubtaskID(subtasks []Subtask) int {
+	maxID := 0
+	for _, sub := range subtasks {
+		if sub.ID > maxID {
+			maxID = sub.ID
+		}
+	}
+	return maxID + 1
+}
+
+// todoProgress renders the Progress column: done/total for todos with a
+// checklist, with a check mark once the todo itself is done.
+func todoProgress(todo RollingTodo) string {
+	progress := ""
+	if len(todo.Subtasks) > 0 {
+		done := 0
+		for _, sub := range todo.Subtasks {
+			if sub.Done {
+				done++
+			}
+		}
+		progress = fmt.Sprintf("%d/%d", done, len(todo.Subtasks))
+	}
+	if todo.Done {
+		progress += " ✓"
+	}
+	return progress
+}
+
+func subtaskRow(sub Subtask, last bool) table.Row {
+	branch := "├─"
+	if last {
+		branch = "└─"
+	}
+	check := "[ ]"
+	if sub.Done {
+		check = "[x]"
+	}
+	return table.Row{"", fmt.Sprintf("  %s %s %s", branch, check, sub.Task), "", "", "", "", "", ""}
+}
+
+// selectedSubtask returns the subtask index under the Rolling cursor, or -1
+// when the cursor is on a todo.
+func (m *model) selectedSubtask() int {
+	cursor := m.tables[1].Cursor()
+	if cursor < 0 || cursor >= len(m.rowSubtask) {
+		return -1
+	}
+	return m.rowSubtask[cursor]
+}
+
+// syncTodoDone completes a todo once every subtask is done and reopens it
+// when one of them is unchecked again.
+func syncTodoDone(todo *RollingTodo) {
+	if len(todo.Subtasks) == 0 {
+		return
+	}
+	allDone := true
+	for _, sub := range todo.Subtasks {
+		if !sub.Done {
+			allDone = false
+			break
+		}
+	}
+	if allDone && !todo.Done {
+		todo.Done = true
+		todo.CompletedAt = time.Now()
+	} else if !allDone && todo.Done {
+		todo.Done = false
+		todo.CompletedAt = time.Time{}
+	}
+}
+
+func (m *model) toggleTodo() {
+	idx := m.selectedIndex(1)
+	if idx == -1 {
+		return
+	}
+	todo := &m.data.RollingTodos[idx]
+
+	if sub := m.selectedSubtask(); sub != -1 {
+		todo.Subtasks[sub].Done = !todo.Subtasks[sub].Done
+		event := "reopened"
+		if todo.Subtasks[sub].Done {
+			event = "completed"
+		}
+		m.logActivity(event, "subtask", todo.ID, todo.Task+" › "+todo.Subtasks[sub].Task)
+		wasDone := todo.Done
+		syncTodoDone(todo)
+		m.statusMsg = fmt.Sprintf("✅ %s (%s)", todo.Subtasks[sub].Task, todoProgress(*todo))
+		if todo.Done && !wasDone {
+			m.statusMsg = fmt.Sprintf("🎉 All subtasks done: %s", todo.Task)
+		}
+	} else {
+		todo.Done = !todo.Done
+		if todo.Done {
+			todo.CompletedAt = time.Now()
+			// Completing the parent checks off its whole checklist
+			for i := range todo.Subtasks {
+				todo.Subtasks[i].Done = true
+			}
+			m.statusMsg = fmt.Sprintf("✅ Done: %s", todo.Task)
+			m.logItem("completed", 3, idx)
+		} else {
+			todo.CompletedAt = time.Time{}
+			m.statusMsg = fmt.Sprintf("↩️ Reopened: %s", todo.Task)
+			m.logItem("reopened", 3, idx)
+		}
+	}
+
+	id := todo.ID
+	m.tables[1].SetRows(m.rollingRows())
+	m.tables[4].SetRows(m.projectRows())
+	if m.boardMode {
+		// Follow the card into its new column
+		m.focusBoardCard(id)
+	}
+	m.save("toggle")
+	m.statusColor = "82"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
+
+func (m *model) toggleExpanded() {
+	idx := m.selectedIndex(1)
+	if idx == -1 {
+		return
+	}
+	todo := m.data.RollingTodos[idx]
+	if len(todo.Subtasks) == 0 {
+		m.statusMsg = "No subtasks yet, press + to add one"
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+		return
+	}
+
+	// Collapsing from a subtask row moves the cursor back to its todo
+	if sub := m.selectedSubtask(); sub != -1 {
+		m.tables[1].SetCursor(m.tables[1].Cursor() - sub - 1)
+	}
+	m.expanded[todo.ID] = !m.expanded[todo.ID]
+	m.tables[1].SetRows(m.rollingRows())
+}
+
+// startSubtaskEdit opens the form for subtask sub of todo row, or for a new
+// subtask when sub is -1.
+func (m *model) startSubtaskEdit(row, sub int) {
+	m.editing = true
+	m.editingTab = 3
+	m.editingRow = row
+	m.editingField = 0
+	m.editingSubtask = true
+	m.editingSubtaskIdx = sub
+
+	m.inputs = make([]textinput.Model, 1)
+	m.inputs[0] = textinput.New()
+	if sub != -1 {
+		m.inputs[0].SetValue(m.data.RollingTodos[row].Subtasks[sub].Task)
+	}
+	m.inputs[0].Focus()
+}
+
+// saveSubtaskEdit adds or renames the subtask being edited and reports
+// whether anything changed; an empty text changes nothing
+func (m *model) saveSubtaskEdit() bool {
+	text := normalizeText(m.inputs[0].Value())
+	if text == "" || m.editingRow < 0 || m.editingRow >= len(m.data.RollingTodos) {
+		return false
+	}
+	todo := &m.data.RollingTodos[m.editingRow]
+
+	if m.editingSubtaskIdx == -1 {
+		todo.Subtasks = append(todo.Subtasks, Subtask{
+			ID:   nextSubtaskID(todo.Subtasks),
+			Task: text,
+		})
+		m.expanded[todo.ID] = true
+		syncTodoDone(todo)
+		return true
+	}
+	if todo.Subtasks[m.editingSubtaskIdx].Task == text {
+		return false
+	}
+	todo.Subtasks[m.editingSubtaskIdx].Task = text
+	return true
+}
+
+func (m *model) deleteSubtask(row, sub int) {
+	todo := &m.data.RollingTodos[row]
+	name := todo.Subtasks[sub].Task
+	m.logActivity("deleted", "subtask", todo.ID, todo.Task+" › "+name)
+	todo.Subtasks = append(todo.Subtasks[:sub], todo.Subtasks[sub+1:]...)
+	syncTodoDone(todo)
+
+	m.tables[1].SetRows(m.rollingRows())
+	m.tables[4].SetRows(m.projectRows())
+	m.statusMsg = fmt.Sprintf("🗑️ Deleted subtask: %s", name)
+	m.statusColor = "196"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
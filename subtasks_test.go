@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+func subtaskEditModel(text string, sub int) model {
+	m := model{editing: true, editingTab: 3, editingRow: 0, editingSubtask: true, editingSubtaskIdx: sub, expanded: map[int]bool{}}
+	m.data.RollingTodos = []RollingTodo{{ID: 1, Task: "Write report", Subtasks: []Subtask{{ID: 1, Task: "Outline"}}}}
+	m.saved = snapshot(m.data)
+	m.inputs = []textinput.Model{textinput.New()}
+	m.inputs[0].SetValue(text)
+	return m
+}
+
+func TestSaveSubtaskEditSkipsUnchanged(t *testing.T) {
+	tempConfigDir(t)
+
+	for _, tc := range []struct {
+		name string
+		text string
+		sub  int
+	}{
+		{"empty new subtask", "", -1},
+		{"blank new subtask", "   ", -1},
+		{"same text", "Outline", 0},
+	} {
+		m := subtaskEditModel(tc.text, tc.sub)
+		if err := m.saveEdit(); err != nil {
+			t.Fatal(err)
+		}
+		if len(m.undoStack) != 0 || len(m.data.RollingTodos[0].Subtasks) != 1 {
+			t.Errorf("%s: %d undo steps, %d subtasks, want nothing saved", tc.name, len(m.undoStack), len(m.data.RollingTodos[0].Subtasks))
+		}
+	}
+
+	m := subtaskEditModel("Draft", -1)
+	if err := m.saveEdit(); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.undoStack) != 1 || len(m.data.RollingTodos[0].Subtasks) != 2 {
+		t.Errorf("%d undo steps, %d subtasks after adding one", len(m.undoStack), len(m.data.RollingTodos[0].Subtasks))
+	}
+}
+
+func TestLoadDataRenumbersDuplicateTodoIDs(t *testing.T) {
+	tempConfigDir(t)
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Numbered by count: deleting #2 of three and adding one gave two #3s
+	config := `{"version": 1, "rolling_todos": [
+		{"id": 1, "task": "Plan"},
+		{"id": 3, "task": "Write"},
+		{"id": 3, "task": "Review"},
+		{"id": 0, "task": "Ship"}
+	]}`
+	if err := os.WriteFile(filepath.Join(configDir, "lif", "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	todos := loadData().RollingTodos
+	seen := map[int]bool{}
+	for _, todo := range todos {
+		if todo.ID <= 0 || seen[todo.ID] {
+			t.Fatalf("IDs not unique: %+v", todos)
+		}
+		seen[todo.ID] = true
+	}
+	if todos[0].ID != 1 || todos[1].ID != 3 {
+		t.Errorf("unique IDs changed: %+v", todos)
+	}
+	if todo := todoByID(todos, 3); todo == nil || todo.Task != "Write" {
+		t.Errorf("todo #3 is %+v, want the first todo that had it", todo)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Background colors for tag chips, picked by hashing the tag name so a tag
+// keeps its color everywhere
+var tagPalette = []string{"25", "29", "53", "58", "94", "96", "130", "31", "61", "66", "100", "132"}
+
+// parseTags reads "#work #home, errands" style input into a list of tags.
+// The leading # is optional; duplicates are dropped case-insensitively.
+func parseTags(input string) []string {
+	tags := []string{}
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		tag := strings.TrimLeft(field, "#")
+		if tag == "" || hasTag(tags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatTags renders tags as plain "#a #b" text, used in table cells and to
+// prefill the edit form.
+func formatTags(tags []string) string {
+	parts := make([]string, len(tags))
+	for i, tag := range tags {
+		parts[i] = "#" + tag
+	}
+	return strings.Join(parts, " ")
+}
+
+func tagStyle(tag string) lipgloss.Style {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(tag)))
+	color := tagPalette[h.Sum32()%uint32(len(tagPalette))]
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("230")).
+		Background(lipgloss.Color(color)).
+		PaddingLeft(1).
+		PaddingRight(1)
+}
+
+// renderTags renders tags as colored chips. Table cells can't hold styled
+// chips (the table truncates by raw width), so this is used outside tables.
+func renderTags(tags []string) string {
+	chips := make([]string, len(tags))
+	for i, tag := range tags {
+		chips[i] = tagStyle(tag).Render("#" + tag)
+	}
+	return strings.Join(chips, " ")
+}
+
+func (m *model) matchesTagFilter(tags []string) bool {
+	return m.tagFilter == "" || hasTag(tags, m.tagFilter)
+}
+
+// selectedTags returns the tags of the item under the cursor on the active tab
+func (m *model) selectedTags() []string {
+	if m.activeTab < 2 || m.activeTab > 5 {
+		return nil
+	}
+	idx := m.selectedIndex(m.activeTab - 2)
+	if idx == -1 {
+		return nil
+	}
+	switch m.activeTab {
+	case 2:
+		return m.data.Dailies[idx].Tags
+	case 3:
+		return m.data.RollingTodos[idx].Tags
+	case 4:
+		return m.data.Reminders[idx].Tags
+	case 5:
+		return m.data.Glossary[idx].Tags
+	}
+	return nil
+}
+
+type tagCount struct {
+	Tag   string
+	Count int
+}
+
+// tagCounts counts tag usage across every item type, most used first
+func tagCounts(data AppData) []tagCount {
+	counts := map[string]int{}
+	names := map[string]string{}
+	add := func(tags []string) {
+		for _, tag := range tags {
+			key := strings.ToLower(tag)
+			if _, ok := names[key]; !ok {
+				names[key] = tag
+			}
+			counts[key]++
+		}
+	}
+	for _, daily := range data.Dailies {
+		add(daily.Tags)
+	}
+	for _, todo := range data.RollingTodos {
+		add(todo.Tags)
+	}
+	for _, reminder := range data.Reminders {
+		add(reminder.Tags)
+	}
+	for _, item := range data.Glossary {
+		add(item.Tags)
+	}
+
+	result := []tagCount{}
+	for key, count := range counts {
+		result = append(result, tagCount{Tag: names[key], Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return lessFold(result[i].Tag, result[j].Tag)
+	})
+	return result
+}
+
+// tagCloud renders every tag as a chip with its usage count; the most used
+// tags are bold.
+func tagCloud(data AppData, width int) string {
+	counts := tagCounts(data)
+	if len(counts) == 0 {
+		return ""
+	}
+	if width <= 0 {
+		width = 80
+	}
+
+	top := counts[0].Count
+	chips := []string{}
+	for _, tc := range counts {
+		style := tagStyle(tc.Tag)
+		if tc.Count*2 > top {
+			style = style.Bold(true)
+		}
+		chips = append(chips, style.Render(fmt.Sprintf("#%s %d", tc.Tag, tc.Count)))
+	}
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(chips, " "))
+}
+
+func (m *model) startTagFilter() {
+	m.filtering = true
+	m.filterInput = textinput.New()
+	m.filterInput.Prompt = "Filter by tag: #"
+	m.filterInput.SetValue(m.tagFilter)
+	m.filterInput.Focus()
+}
+
+func (m *model) setTagFilter(tag string) {
+	m.tagFilter = strings.TrimLeft(strings.TrimSpace(tag), "#")
+	m.refreshTables()
+	for i := range m.tables {
+		m.tables[i].GotoTop()
+	}
+}
+
+func (m model) handleFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.filtering = false
+		return m, nil
+	case "enter":
+		m.filtering = false
+		m.setTagFilter(m.filterInput.Value())
+		if m.tagFilter == "" {
+			return m, showStatus("Tag filter cleared", "86")
+		}
+		return m, showStatus(fmt.Sprintf("Showing items tagged #%s", m.tagFilter), "86")
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	return m, cmd
+}
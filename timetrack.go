@@ -0,0 +1,280 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Time tracking on dailies and rolling todos. One timer runs at a time; the
+// running entry has a zero End and is shown in the header, refreshed by the
+// regular tick.
+
+type TimeEntry struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+var timerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+
+func (e TimeEntry) running() bool {
+	return e.End.IsZero()
+}
+
+func (e TimeEntry) duration(now time.Time) time.Duration {
+	if e.running() {
+		return now.Sub(e.Start)
+	}
+	return e.End.Sub(e.Start)
+}
+
+func trackedTime(entries []TimeEntry, now time.Time) time.Duration {
+	var total time.Duration
+	for _, entry := range entries {
+		total += entry.duration(now)
+	}
+	return total
+}
+
+// formatClock renders a running timer as h:mm:ss
+func formatClock(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// formatTracked renders a total as "2h 05m" or "12m"
+func formatTracked(d time.Duration) string {
+	d = d.Truncate(time.Minute)
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// timeEntries returns the entries of the item at idx on the Dailies (2) or
+// Rolling (3) tab
+func (m *model) timeEntries(tab, idx int) *[]TimeEntry {
+	if tab == 2 {
+		return &m.data.Dailies[idx].TimeEntries
+	}
+	return &m.data.RollingTodos[idx].TimeEntries
+}
+
+// runningTimer finds the item whose timer is running, or tab 0 when none is
+func (m *model) runningTimer() (tab, idx int) {
+	for i, daily := range m.data.Dailies {
+		if n := len(daily.TimeEntries); n > 0 && daily.TimeEntries[n-1].running() {
+			return 2, i
+		}
+	}
+	for i, todo := range m.data.RollingTodos {
+		if n := len(todo.TimeEntries); n > 0 && todo.TimeEntries[n-1].running() {
+			return 3, i
+		}
+	}
+	return 0, -1
+}
+
+func (m *model) itemName(tab, idx int) string {
+	if tab == 2 {
+		return m.data.Dailies[idx].Task
+	}
+	return m.data.RollingTodos[idx].Task
+}
+
+func (m *model) stopTimer(tab, idx int, now time.Time) {
+	entries := *m.timeEntries(tab, idx)
+	entries[len(entries)-1].End = now
+	m.logItem("timer stopped", tab, idx)
+}
+
+// toggleTimer starts the timer on the selected item, stopping whichever timer
+// was running, or stops it when it was already running on that item.
+func (m *model) toggleTimer() {
+	tab := m.activeTab
+	idx := m.selectedIndex(tab - 2)
+	if idx == -1 {
+		return
+	}
+	now := time.Now()
+
+	runningTab, runningIdx := m.runningTimer()
+	if runningTab != 0 {
+		m.stopTimer(runningTab, runningIdx, now)
+	}
+	if runningTab == tab && runningIdx == idx {
+		total := trackedTime(*m.timeEntries(tab, idx), now)
+		m.save("stop timer")
+		m.statusMsg = fmt.Sprintf("⏹️ Stopped timer: %s (%s total)", m.itemName(tab, idx), formatTracked(total))
+		m.statusColor = "86"
+		m.statusExpiry = time.Now().Add(3 * time.Second)
+		return
+	}
+
+	entries := m.timeEntries(tab, idx)
+	*entries = append(*entries, TimeEntry{Start: now})
+	m.logItem("timer started", tab, idx)
+	m.save("start timer")
+	m.statusMsg = fmt.Sprintf("⏱️ Tracking: %s", m.itemName(tab, idx))
+	m.statusColor = "82"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
+
+// timerHeader shows the running timer next to the app title
+func (m *model) timerHeader() string {
+	tab, idx := m.runningTimer()
+	if tab == 0 {
+		return ""
+	}
+	entries := *m.timeEntries(tab, idx)
+	current := entries[len(entries)-1].duration(time.Now())
+	return timerStyle.Render(fmt.Sprintf("⏱ %s %s", m.itemName(tab, idx), formatClock(current)))
+}
+
+// selectedTracked describes the time and pomodoros spent on the selected item
+func (m *model) selectedTracked() string {
+	if m.activeTab != 2 && m.activeTab != 3 {
+		return ""
+	}
+	idx := m.selectedIndex(m.activeTab - 2)
+	if idx == -1 {
+		return ""
+	}
+	parts := []string{}
+	if total := trackedTime(*m.timeEntries(m.activeTab, idx), time.Now()); total > 0 {
+		parts = append(parts, fmt.Sprintf("⏱ %s tracked", formatTracked(total)))
+	}
+	if m.activeTab == 3 && m.data.RollingTodos[idx].Pomodoros > 0 {
+		parts = append(parts, fmt.Sprintf("🍅 %d pomodoros", m.data.RollingTodos[idx].Pomodoros))
+	}
+	return bulletStyle.Render(strings.Join(parts, " • "))
+}
+
+// addByDay adds the time between start and end to totals keyed by local
+// date, splitting entries that run past midnight.
+func addByDay(totals map[string]time.Duration, start, end time.Time) {
+	for start.Before(end) {
+		y, mo, d := start.Date()
+		midnight := time.Date(y, mo, d+1, 0, 0, 0, 0, start.Location())
+		until := end
+		if midnight.Before(end) {
+			until = midnight
+		}
+		totals[start.Format("2006-01-02")] += until.Sub(start)
+		start = until
+	}
+}
+
+type trackedTotal struct {
+	Name  string
+	Total time.Duration
+}
+
+func sortedTotals(totals map[string]time.Duration) []trackedTotal {
+	result := []trackedTotal{}
+	for name, total := range totals {
+		result = append(result, trackedTotal{Name: name, Total: total})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total != result[j].Total {
+			return result[i].Total > result[j].Total
+		}
+		return lessFold(result[i].Name, result[j].Name)
+	})
+	return result
+}
+
+// timeReport totals the tracked time per day (last 7 days), per category and
+// per item
+func timeReport(data AppData, now time.Time) string {
+	byDay := map[string]time.Duration{}
+	byCategory := map[string]time.Duration{}
+	byItem := map[string]time.Duration{}
+	categoryNames := map[string]string{}
+
+	add := func(name, category string, entries []TimeEntry) {
+		for _, entry := range entries {
+			end := entry.End
+			if entry.running() {
+				end = now
+			}
+			addByDay(byDay, entry.Start, end)
+		}
+		total := trackedTime(entries, now)
+		if total == 0 {
+			return
+		}
+		byItem[name] += total
+		key := strings.ToLower(category)
+		if _, ok := categoryNames[key]; !ok {
+			categoryNames[key] = category
+			if category == "" {
+				categoryNames[key] = "Uncategorized"
+			}
+		}
+		byCategory[categoryNames[key]] += total
+	}
+	for _, daily := range data.Dailies {
+		add("📋 "+daily.Task, daily.Category, daily.TimeEntries)
+	}
+	for _, todo := range data.RollingTodos {
+		add(fmt.Sprintf("🔄 #%d %s", todo.ID, todo.Task), todo.Category, todo.TimeEntries)
+	}
+
+	if len(byItem) == 0 {
+		return "No time tracked yet. Press t on a daily task or rolling todo to start a timer."
+	}
+
+	lines := []string{headerStyle.Render("By day")}
+	y, mo, d := now.Date()
+	for i := 0; i < 7; i++ {
+		day := time.Date(y, mo, d-i, 0, 0, 0, 0, now.Location())
+		label := day.Format("Mon Jan 2")
+		if i == 0 {
+			label = "Today"
+		}
+		lines = append(lines, fmt.Sprintf("  %-12s %8s", label, formatTracked(byDay[day.Format("2006-01-02")])))
+	}
+
+	lines = append(lines, "", headerStyle.Render("By category"))
+	for _, total := range sortedTotals(byCategory) {
+		lines = append(lines, fmt.Sprintf("  %-20s %8s", total.Name, formatTracked(total.Total)))
+	}
+
+	lines = append(lines, "", headerStyle.Render("By item"))
+	for i, total := range sortedTotals(byItem) {
+		if i == 15 {
+			lines = append(lines, bulletStyle.Render(fmt.Sprintf("  … %d more", len(byItem)-15)))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  %-40s %8s", total.Name, formatTracked(total.Total)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m model) handleReportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "w":
+		m.reportOpen = false
+	}
+	return m, nil
+}
+
+func (m model) reportView() string {
+	header := headerStyle.Render("⏱️ Time report")
+	if timer := m.timerHeader(); timer != "" {
+		header += "  " + timer
+	}
+	footer := keyStyle.Render("esc") + ": " + actionStyle.Render("close")
+	return lipgloss.JoinVertical(lipgloss.Top,
+		header,
+		"",
+		timeReport(m.data, time.Now()),
+		"",
+		footer,
+	)
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Deleted items are kept in a trash in the config file until restored or
+// purged. Exactly one of the item fields is set.
+type TrashItem struct {
+	DeletedAt time.Time     `json:"deleted_at"`
+	Daily     *Daily        `json:"daily,omitempty"`
+	Todo      *RollingTodo  `json:"todo,omitempty"`
+	Reminder  *Reminder     `json:"reminder,omitempty"`
+	Glossary  *GlossaryItem `json:"glossary,omitempty"`
+	Project   *Project      `json:"project,omitempty"`
+}
+
+// Oldest entries are dropped once the trash holds more than this
+const maxTrash = 200
+
+func (t TrashItem) kind() string {
+	switch {
+	case t.Daily != nil:
+		return "Daily"
+	case t.Todo != nil:
+		return "Todo"
+	case t.Reminder != nil:
+		return "Reminder"
+	case t.Glossary != nil:
+		return "Glossary"
+	case t.Project != nil:
+		return "Project"
+	}
+	return ""
+}
+
+func (t TrashItem) name() string {
+	switch {
+	case t.Daily != nil:
+		return t.Daily.Task
+	case t.Todo != nil:
+		return t.Todo.Task
+	case t.Reminder != nil:
+		return t.Reminder.Reminder
+	case t.Glossary != nil:
+		return oneLine(t.Glossary.Command)
+	case t.Project != nil:
+		return t.Project.Name
+	}
+	return ""
+}
+
+// trashItem copies the item at idx on a tab into the trash; call it before
+// removing the item.
+func (m *model) trashItem(tab, idx int) {
+	item := TrashItem{DeletedAt: time.Now()}
+	switch tab {
+	case 2:
+		daily := m.data.Dailies[idx]
+		item.Daily = &daily
+	case 3:
+		todo := m.data.RollingTodos[idx]
+		item.Todo = &todo
+	case 4:
+		reminder := m.data.Reminders[idx]
+		item.Reminder = &reminder
+	case 5:
+		glossary := m.data.Glossary[idx]
+		item.Glossary = &glossary
+	case 6:
+		project := m.data.Projects[idx]
+		item.Project = &project
+	default:
+		return
+	}
+	m.data.Trash = append(m.data.Trash, item)
+	if len(m.data.Trash) > maxTrash {
+		m.data.Trash = m.data.Trash[len(m.data.Trash)-maxTrash:]
+	}
+}
+
+// restoreTrashItem puts a trashed item back. IDs taken in the meantime are
+// replaced, and links to todos or projects that no longer exist are dropped.
+func restoreTrashItem(data *AppData, item TrashItem) {
+	switch {
+	case item.Daily != nil:
+		daily := *item.Daily
+		for _, existing := range data.Dailies {
+			if existing.ID == daily.ID {
+				daily.ID = nextDailyID(data.Dailies)
+				break
+			}
+		}
+		daily.Order = 0
+		data.Dailies = append(data.Dailies, daily)
+	case item.Todo != nil:
+		todo := *item.Todo
+		if todoByID(data.RollingTodos, todo.ID) != nil {
+			todo.ID = nextTodoID(data.RollingTodos)
+		}
+		blockedBy := []int{}
+		for _, id := range todo.BlockedBy {
+			if todoByID(data.RollingTodos, id) != nil {
+				blockedBy = append(blockedBy, id)
+			}
+		}
+		todo.BlockedBy = blockedBy
+		if projectByID(data.Projects, todo.ProjectID) == nil {
+			todo.ProjectID = 0
+		}
+		todo.Order = 0
+		data.RollingTodos = append(data.RollingTodos, todo)
+	case item.Reminder != nil:
+		reminder := *item.Reminder
+		for _, existing := range data.Reminders {
+			if existing.ID == reminder.ID {
+				reminder.ID = nextReminderID(data.Reminders)
+				break
+			}
+		}
+		reminder.Order = 0
+		data.Reminders = append(data.Reminders, reminder)
+	case item.Glossary != nil:
+		glossary := *item.Glossary
+		for _, existing := range data.Glossary {
+			if existing.ID == glossary.ID {
+				glossary.ID = nextGlossaryID(data.Glossary)
+				break
+			}
+		}
+		glossary.Order = 0
+		data.Glossary = append(data.Glossary, glossary)
+	case item.Project != nil:
+		project := *item.Project
+		if projectByID(data.Projects, project.ID) != nil {
+			project.ID = nextProjectID(data.Projects)
+		}
+		project.Order = 0
+		data.Projects = append(data.Projects, project)
+	}
+}
+
+func (m *model) startTrash() {
+	if len(m.data.Trash) == 0 {
+		m.statusMsg = "Trash is empty"
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(2 * time.Second)
+		return
+	}
+	m.trashOpen = true
+	m.trashCursor = 0
+}
+
+// trashAt maps a cursor position to a trash index; the list shows the most
+// recently deleted items first
+func (m *model) trashAt(cursor int) int {
+	return len(m.data.Trash) - 1 - cursor
+}
+
+func (m model) handleTrashKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "T":
+		m.trashOpen = false
+	case "up", "k":
+		if m.trashCursor > 0 {
+			m.trashCursor--
+		}
+	case "down", "j":
+		if m.trashCursor < len(m.data.Trash)-1 {
+			m.trashCursor++
+		}
+	case "enter", "r":
+		if len(m.data.Trash) == 0 {
+			return m, nil
+		}
+		idx := m.trashAt(m.trashCursor)
+		item := m.data.Trash[idx]
+		restoreTrashItem(&m.data, item)
+		m.logActivity("restored", strings.ToLower(item.kind()), 0, item.name())
+		m.data.Trash = append(m.data.Trash[:idx], m.data.Trash[idx+1:]...)
+		m.refreshTables()
+		m.save("restore " + item.name())
+		if m.trashCursor >= len(m.data.Trash) {
+			m.trashCursor = len(m.data.Trash) - 1
+		}
+		if len(m.data.Trash) == 0 {
+			m.trashOpen = false
+		}
+		return m, showStatus(fmt.Sprintf("♻️ Restored %s: %s", strings.ToLower(item.kind()), item.name()), "82")
+	case "x", "delete":
+		if len(m.data.Trash) == 0 {
+			return m, nil
+		}
+		idx := m.trashAt(m.trashCursor)
+		item := m.data.Trash[idx]
+		m.data.Trash = append(m.data.Trash[:idx], m.data.Trash[idx+1:]...)
+		m.logActivity("purged", strings.ToLower(item.kind()), 0, item.name())
+		m.save("purge " + item.name())
+		if m.trashCursor >= len(m.data.Trash) {
+			m.trashCursor = len(m.data.Trash) - 1
+		}
+		if len(m.data.Trash) == 0 {
+			m.trashOpen = false
+		}
+		return m, showStatus(fmt.Sprintf("🗑️ Purged: %s", item.name()), "196")
+	case "E":
+		count := len(m.data.Trash)
+		m.data.Trash = nil
+		m.trashOpen = false
+		m.logActivity("purged", "trash", 0, fmt.Sprintf("%d items", count))
+		m.save("empty trash")
+		return m, showStatus(fmt.Sprintf("🗑️ Emptied trash (%d items)", count), "196")
+	}
+	return m, nil
+}
+
+func (m model) trashView() string {
+	header := headerStyle.Render(fmt.Sprintf("🗑️ Trash (%d)", len(m.data.Trash)))
+
+	// Keep the cursor visible in small terminals
+	visible := len(m.data.Trash)
+	if m.height > 0 && visible > m.height-6 {
+		visible = m.height - 6
+		if visible < 5 {
+			visible = 5
+		}
+	}
+	start := 0
+	if m.trashCursor >= visible {
+		start = m.trashCursor - visible + 1
+	}
+	end := start + visible
+	if end > len(m.data.Trash) {
+		end = len(m.data.Trash)
+	}
+
+	lines := []string{}
+	for i := start; i < end; i++ {
+		item := m.data.Trash[m.trashAt(i)]
+		line := fmt.Sprintf("%-9s %s %s", item.kind(), item.name(),
+			bulletStyle.Render(item.DeletedAt.Format("Jan 2 15:04")))
+		if i == m.trashCursor {
+			line = activeTabStyle.Render(">") + " " + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	footer := keyStyle.Render("↑↓") + ": " + actionStyle.Render("navigate") + bulletStyle.Render(" • ") +
+		keyStyle.Render("enter/r") + ": " + actionStyle.Render("restore") + bulletStyle.Render(" • ") +
+		keyStyle.Render("x") + ": " + actionStyle.Render("purge") + bulletStyle.Render(" • ") +
+		keyStyle.Render("E") + ": " + actionStyle.Render("empty trash") + bulletStyle.Render(" • ") +
+		keyStyle.Render("esc") + ": " + actionStyle.Render("close")
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		header,
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		footer,
+	)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Undo/redo keeps snapshots of the data as it was saved before each change.
+// Everything that changes items goes through m.save, so every mutation can be
+// undone the same way.
+
+const maxUndo = 50
+
+type undoEntry struct {
+	action string
+	data   []byte
+}
+
+func snapshot(data AppData) []byte {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// save writes the data and records the state before it for undo. action
+// describes the change in the undo/redo status line.
+func (m *model) save(action string) {
+	if m.saved != nil {
+		m.undoStack = append(m.undoStack, undoEntry{action: action, data: m.saved})
+		if len(m.undoStack) > maxUndo {
+			m.undoStack = m.undoStack[len(m.undoStack)-maxUndo:]
+		}
+		m.redoStack = nil
+	}
+	saveData(m.data)
+	m.saved = snapshot(m.data)
+}
+
+// saveQuiet writes the data without an undo step, for changes that aren't
+// edits: view settings, the pomodoro timer and reminders firing.
+func (m *model) saveQuiet() {
+	saveData(m.data)
+	m.saved = snapshot(m.data)
+}
+
+func (m *model) undo() {
+	if len(m.undoStack) == 0 {
+		m.statusMsg = "Nothing to undo"
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(2 * time.Second)
+		return
+	}
+	entry := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+	m.redoStack = append(m.redoStack, undoEntry{action: entry.action, data: m.saved})
+	m.restore(entry.data)
+	m.logActivity("undo", "", 0, entry.action)
+
+	m.statusMsg = fmt.Sprintf("↩️ Undid %s", entry.action)
+	m.statusColor = "86"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
+
+func (m *model) redo() {
+	if len(m.redoStack) == 0 {
+		m.statusMsg = "Nothing to redo"
+		m.statusColor = "226"
+		m.statusExpiry = time.Now().Add(2 * time.Second)
+		return
+	}
+	entry := m.redoStack[len(m.redoStack)-1]
+	m.redoStack = m.redoStack[:len(m.redoStack)-1]
+	m.undoStack = append(m.undoStack, undoEntry{action: entry.action, data: m.saved})
+	m.restore(entry.data)
+	m.logActivity("redo", "", 0, entry.action)
+
+	m.statusMsg = fmt.Sprintf("↪️ Redid %s", entry.action)
+	m.statusColor = "86"
+	m.statusExpiry = time.Now().Add(3 * time.Second)
+}
+
+// restore replaces the data with a snapshot. Sort choices, the pomodoro timer
+// and the pomodoros counted on todos, the stopwatch time (only its laps are
+// undone), Do Not Disturb and reminders and alerts that have fired since are
+// kept, so undo never changes the view, stops a timer, loses a pomodoro or
+// rings a reminder a second time.
+func (m *model) restore(b []byte) {
+	var data AppData
+	if err := json.Unmarshal(b, &data); err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Could not restore: %v", err)
+		m.statusColor = "196"
+		return
+	}
+	catchUpCountdowns(&data, time.Now())
+	data.SortPrefs = m.data.SortPrefs
+	data.Pomodoro = m.data.Pomodoro
+	data.Stopwatch.StartedAt = m.data.Stopwatch.StartedAt
+	data.Stopwatch.Elapsed = m.data.Stopwatch.Elapsed
+	data.Notify.DoNotDisturb = m.data.Notify.DoNotDisturb
+	data.Held = m.data.Held
+	for i := range data.Reminders {
+		for _, current := range m.data.Reminders {
+			if current.ID == data.Reminders[i].ID && current.LeadTarget.Equal(data.Reminders[i].TargetTime) {
+				data.Reminders[i].LeadTarget = current.LeadTarget
+				data.Reminders[i].LeadsSent = current.LeadsSent
+			}
+			if current.ID == data.Reminders[i].ID && current.Notified && current.TargetTime.Equal(data.Reminders[i].TargetTime) {
+				data.Reminders[i].Notified = true
+				data.Reminders[i].Status = current.Status
+				data.Reminders[i].Nags = current.Nags
+				data.Reminders[i].LastNotified = current.LastNotified
+			}
+		}
+	}
+	// Pomodoros are counted without an undo step, like the daily totals
+	for i := range data.RollingTodos {
+		if current := todoByID(m.data.RollingTodos, data.RollingTodos[i].ID); current != nil {
+			data.RollingTodos[i].Pomodoros = current.Pomodoros
+		}
+	}
+
+	m.data = data
+	m.marked = map[int]bool{}
+	m.lapCursor = 0
+	m.refreshTables()
+	if m.boardMode {
+		m.syncBoardCursor()
+	}
+	m.saveQuiet()
+}